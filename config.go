@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/choonkeat/graphql-to-openapi/converter"
+	"gopkg.in/yaml.v3"
+)
+
+// fileConfig is the layout of a -config file: the converter settings plus the
+// options only the CLI has
+type fileConfig struct {
+	converter.Config `yaml:",inline"`
+	Schema           string `json:"schema,omitempty" yaml:"schema,omitempty"`
+	SchemaFormat     string `json:"schemaFormat,omitempty" yaml:"schemaFormat,omitempty"`
+	Output           string `json:"output,omitempty" yaml:"output,omitempty"`
+	Format           string `json:"format,omitempty" yaml:"format,omitempty"`
+}
+
+// configFlags maps each flag that can also be set in a config file to the
+// converter.Config fields it fills. -base-url replaces the file's baseUrl as
+// well as its servers, since the flags build the whole server list.
+var configFlags = map[string][]string{
+	"title":                     {"Title"},
+	"version":                   {"Version"},
+	"contact-name":              {"ContactName"},
+	"contact-email":             {"ContactEmail"},
+	"contact-url":               {"ContactURL"},
+	"license-name":              {"LicenseName"},
+	"license-url":               {"LicenseURL"},
+	"terms-url":                 {"TermsOfService"},
+	"external-docs-url":         {"ExternalDocsURL"},
+	"external-docs-description": {"ExternalDocsDescription"},
+	"base-url":                  {"Servers", "BaseURL"},
+	"server-description":        {"Servers", "BaseURL"},
+	"path-prefix":               {"PathPrefix"},
+	"prefix-in-server-url":      {"PrefixInServerURL"},
+	"path-case":                 {"PathCase"},
+	"openapi-version":           {"OpenAPIVersion"},
+	"no-footer":                 {"OmitFooter"},
+	"int-format":                {"IntFormat"},
+	"id-format":                 {"IDFormat"},
+	"strict-objects":            {"StrictObjects"},
+	"detect-rest-patterns":      {"DetectRESTPatterns"},
+	"include-graphql-endpoint":  {"IncludeGraphQLEndpoint"},
+	"disable-rest-resources":    {"DisableRESTResources"},
+	"include-types":             {"IncludeTypes"},
+	"exclude-types":             {"ExcludeTypes"},
+	"include-operations":        {"IncludeOperations"},
+	"exclude-operations":        {"ExcludeOperations"},
+	"pluralize-suffixes":        {"CustomPlurals"},
+	"pluralize-es-suffixes":     {"PluralizeSuffixesES"},
+	"pluralize-ies-suffix":      {"PluralizeSuffixIES"},
+	"pluralize-default-suffix":  {"PluralizeDefaultSuffix"},
+	"crud-prefix-create":        {"CRUDPrefixCreate"},
+	"crud-prefix-update":        {"CRUDPrefixUpdate"},
+	"crud-prefix-delete":        {"CRUDPrefixDelete"},
+	"auth-directive":            {"AuthDirective"},
+	"security-scheme":           {"SecuritySchemeType"},
+}
+
+// applyConfigFile overlays the settings in a YAML or JSON config file onto
+// config and opts, which hold the flag values. Keys missing from the file keep
+// the flag defaults, and flags given on the command line win over the file.
+func applyConfigFile(path string, config *converter.Config, opts *runOptions) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	file := fileConfig{
+		Config:       *config,
+		Schema:       opts.schemaFile,
+		SchemaFormat: opts.schemaFormat,
+		Output:       opts.outputFile,
+		Format:       opts.format,
+	}
+	// Decoding into a map adds to it, so the file's maps (e.g. customPlurals)
+	// would extend the flag values instead of replacing them like every other
+	// setting: decode into empty maps and restore the ones the file leaves out
+	fromFlags := reflect.ValueOf(*config)
+	merged := reflect.ValueOf(&file.Config).Elem()
+	for i := 0; i < merged.NumField(); i++ {
+		if merged.Field(i).Kind() == reflect.Map && merged.Field(i).CanSet() {
+			merged.Field(i).SetZero()
+		}
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&file)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&file)
+	}
+	if err != nil && err != io.EOF { // An empty file sets nothing
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	for i := 0; i < merged.NumField(); i++ {
+		if field := merged.Field(i); field.Kind() == reflect.Map && field.CanSet() && field.IsNil() {
+			field.Set(fromFlags.Field(i))
+		}
+	}
+	// A title or version in the file, like the flags, wins over the schema's @info
+	var keys map[string]interface{}
+	if yaml.Unmarshal(data, &keys) == nil { // JSON parses as YAML too
+		_, title := keys["title"]
+		_, version := keys["version"]
+		file.TitleSet = config.TitleSet || title
+		file.VersionSet = config.VersionSet || version
+	}
+
+	explicit := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+	for name, fields := range configFlags {
+		if explicit[name] {
+			for _, field := range fields {
+				merged.FieldByName(field).Set(fromFlags.FieldByName(field))
+			}
+		}
+	}
+	*config = file.Config
+
+	if !explicit["schema"] {
+		opts.schemaFile = file.Schema
+	}
+	if !explicit["schema-format"] {
+		opts.schemaFormat = file.SchemaFormat
+	}
+	if !explicit["output"] {
+		opts.outputFile = file.Output
+	}
+	if !explicit["format"] {
+		opts.format = file.Format
+	}
+	return nil
+}
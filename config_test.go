@@ -0,0 +1,117 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/choonkeat/graphql-to-openapi/converter"
+)
+
+func TestApplyConfigFileBaseURLFlag(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := "title: From File\nbaseUrl: https://file.example.com\nservers:\n  - url: https://other.example.com\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		wantBaseURL string
+		wantServers []converter.Server
+	}{
+		{"file only", nil, "https://file.example.com", []converter.Server{{URL: "https://other.example.com"}}},
+		{"flag wins", []string{"-base-url", "https://flag.example.com"}, "", []converter.Server{{URL: "https://flag.example.com"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saved := flag.CommandLine
+			defer func() { flag.CommandLine = saved }()
+			flag.CommandLine = flag.NewFlagSet("test", flag.ContinueOnError)
+			var baseURLs stringList
+			flag.Var(&baseURLs, "base-url", "")
+			if err := flag.CommandLine.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+
+			config := converter.Config{}
+			for _, url := range baseURLs {
+				config.Servers = append(config.Servers, converter.Server{URL: url})
+			}
+			if err := applyConfigFile(path, &config, &runOptions{}); err != nil {
+				t.Fatal(err)
+			}
+			if config.BaseURL != tt.wantBaseURL {
+				t.Errorf("BaseURL = %q, want %q", config.BaseURL, tt.wantBaseURL)
+			}
+			if !reflect.DeepEqual(config.Servers, tt.wantServers) {
+				t.Errorf("Servers = %+v, want %+v", config.Servers, tt.wantServers)
+			}
+			if config.Title != "From File" {
+				t.Errorf("Title = %q, want the file's", config.Title)
+			}
+		})
+	}
+}
+
+func TestApplyConfigFileMarksTitleSet(t *testing.T) {
+	tests := []struct {
+		file, data           string
+		titleSet, versionSet bool
+	}{
+		{"config.yaml", "title: Converted from GraphQL\n", true, false},
+		{"config.json", `{"version": "1.0.0"}`, false, true},
+		{"other.yaml", "pathPrefix: /api\n", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			saved := flag.CommandLine
+			defer func() { flag.CommandLine = saved }()
+			flag.CommandLine = flag.NewFlagSet("test", flag.ContinueOnError)
+
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.data), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			config := converter.DefaultConfig()
+			if err := applyConfigFile(path, &config, &runOptions{}); err != nil {
+				t.Fatal(err)
+			}
+			if config.TitleSet != tt.titleSet || config.VersionSet != tt.versionSet {
+				t.Errorf("TitleSet = %v, VersionSet = %v, want %v, %v", config.TitleSet, config.VersionSet, tt.titleSet, tt.versionSet)
+			}
+		})
+	}
+}
+
+func TestApplyConfigFileReplacesMaps(t *testing.T) {
+	saved := flag.CommandLine
+	defer func() { flag.CommandLine = saved }()
+	flag.CommandLine = flag.NewFlagSet("test", flag.ContinueOnError)
+
+	for file, data := range map[string]string{
+		"config.yaml": "customPlurals:\n  person: people\n",
+		"config.json": `{"customPlurals": {"person": "people"}}`,
+	} {
+		path := filepath.Join(t.TempDir(), file)
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		config := converter.Config{
+			CustomPlurals:  map[string]string{"datum": "data"},
+			ScalarMappings: map[string]string{"Money": "string"},
+		}
+		if err := applyConfigFile(path, &config, &runOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if want := map[string]string{"person": "people"}; !reflect.DeepEqual(config.CustomPlurals, want) {
+			t.Errorf("%s: CustomPlurals = %v, want %v", file, config.CustomPlurals, want)
+		}
+		if want := map[string]string{"Money": "string"}; !reflect.DeepEqual(config.ScalarMappings, want) {
+			t.Errorf("%s: ScalarMappings = %v, want the flag value kept", file, config.ScalarMappings)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const constraintDirective = `
+directive @constraint(min: Float, max: Float, exclusiveMin: Float, exclusiveMax: Float, multipleOf: Float, positive: Boolean) on FIELD_DEFINITION | INPUT_FIELD_DEFINITION | ARGUMENT_DEFINITION
+`
+
+// constraintSchema converts a Product type whose price field carries
+// constraint, returning the price property as JSON
+func constraintSchema(t *testing.T, version, constraint string) string {
+	t.Helper()
+	config := DefaultConfig()
+	config.OpenAPIVersion = version
+	doc := convertSDL(t, config, constraintDirective+`
+type Product { id: ID!, price: Float! `+constraint+` }
+type Query { product(id: ID!): Product }
+`)
+	return toJSON(t, doc.Components.Schemas["Product"].Properties["price"])
+}
+
+func TestExclusiveConstraints(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    string
+		constraint string
+		want       string
+	}{
+		{"3.0 flags the bound", "3.0.0", `@constraint(exclusiveMin: 0, multipleOf: 0.5)`,
+			`{"type":"number","format":"double","minimum":0,"exclusiveMinimum":true,"multipleOf":0.5}`},
+		{"3.1 uses numbers", "3.1.0", `@constraint(exclusiveMin: 0, multipleOf: 0.5)`,
+			`{"type":"number","format":"double","exclusiveMinimum":0,"multipleOf":0.5}`},
+		{"3.0 keeps a tighter inclusive bound", "3.0.0", `@constraint(min: 5, exclusiveMin: 0, exclusiveMax: 100)`,
+			`{"type":"number","format":"double","minimum":5,"maximum":100,"exclusiveMaximum":true}`},
+		{"3.1 keeps both bounds", "3.1.0", `@constraint(min: 5, exclusiveMin: 0)`,
+			`{"type":"number","format":"double","minimum":5,"exclusiveMinimum":0}`},
+		{"3.0 positive", "3.0.0", `@constraint(positive: true)`,
+			`{"type":"number","format":"double","minimum":0,"exclusiveMinimum":true}`},
+		{"3.1 positive", "3.1.0", `@constraint(positive: true)`,
+			`{"type":"number","format":"double","exclusiveMinimum":0}`},
+		{"explicit bound beats positive", "3.1.0", `@constraint(exclusiveMin: 10, positive: true)`,
+			`{"type":"number","format":"double","exclusiveMinimum":10}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := constraintSchema(t, tt.version, tt.constraint); got != tt.want {
+				t.Errorf("price = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNonFiniteConstraints(t *testing.T) {
+	for _, value := range []string{`"NaN"`, `"Inf"`, `"-Infinity"`} {
+		t.Run(value, func(t *testing.T) {
+			c := New(DefaultConfig())
+			doc, err := c.Convert(`
+directive @constraint(min: String, max: Float) on FIELD_DEFINITION
+type Product { id: ID!, price: Float! @constraint(min: ` + value + `, max: 100) }
+type Query { product(id: ID!): Product }
+`)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := toJSON(t, doc.Components.Schemas["Product"].Properties["price"]), `{"type":"number","format":"double","maximum":100}`; got != want {
+				t.Errorf("price = %s, want %s", got, want)
+			}
+			if len(c.Warnings()) != 1 {
+				t.Errorf("warnings = %v, want one about %s", c.Warnings(), value)
+			}
+			if _, err := MarshalYAML(doc); err != nil {
+				t.Errorf("MarshalYAML: %v", err)
+			}
+		})
+	}
+}
+
+func TestListConstraints(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+directive @constraint(minItems: Int, maxItems: Int, uniqueItems: Boolean) on FIELD_DEFINITION | ARGUMENT_DEFINITION
+type User { id: ID!, tags: [String!]! @constraint(minItems: 1, maxItems: 5, uniqueItems: true) }
+type Query { user(id: ID!): User }
+`)
+	want := `{"type":"array","items":{"type":"string"},"minItems":1,"maxItems":5,"uniqueItems":true}`
+	if got := toJSON(t, doc.Components.Schemas["User"].Properties["tags"]); got != want {
+		t.Errorf("tags = %s, want %s", got, want)
+	}
+}
+
+func TestConstraintValueCoercion(t *testing.T) {
+	value := func(kind ast.ValueKind, raw string) *ast.Value { return &ast.Value{Kind: kind, Raw: raw} }
+	tests := []struct {
+		name  string
+		value *ast.Value
+		int   string
+		float string
+		bool  string
+	}{
+		{"int", value(ast.IntValue, "3"), "3", "3", "null"},
+		{"float", value(ast.FloatValue, "2.5"), "null", "2.5", "null"},
+		{"numeric string", value(ast.StringValue, " 4 "), "4", "4", "null"},
+		{"boolean", value(ast.BooleanValue, "true"), "null", "null", "true"},
+		{"boolean string", value(ast.StringValue, "false"), "null", "null", "false"},
+		{"null", value(ast.NullValue, "null"), "null", "null", "null"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := toJSON(t, constraintInt(tt.value)); got != tt.int {
+				t.Errorf("constraintInt = %s, want %s", got, tt.int)
+			}
+			if got := toJSON(t, constraintFloat(tt.value)); got != tt.float {
+				t.Errorf("constraintFloat = %s, want %s", got, tt.float)
+			}
+			if got := toJSON(t, constraintBool(tt.value)); got != tt.bool {
+				t.Errorf("constraintBool = %s, want %s", got, tt.bool)
+			}
+		})
+	}
+}
+
+func TestConstraintLiterals(t *testing.T) {
+	tests := []struct {
+		raw   string
+		int   string
+		float string
+	}{
+		{"10", "10", "10"},
+		{"10abc", "null", "null"},
+		{"-5", "-5", "-5"},
+		{"1.5e3", "null", "1500"},
+		{`"20"`, "20", "20"},
+		{`"10abc"`, "null", "null"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			value := &ast.Value{Kind: ast.StringValue, Raw: tt.raw}
+			if got := toJSON(t, constraintInt(value)); got != tt.int {
+				t.Errorf("constraintInt = %s, want %s", got, tt.int)
+			}
+			if got := toJSON(t, constraintFloat(value)); got != tt.float {
+				t.Errorf("constraintFloat = %s, want %s", got, tt.float)
+			}
+		})
+	}
+
+	for raw, want := range map[string]string{
+		"true": "true", "false": "false", `"true"`: "true", `"false"`: "false",
+		"1": "null", "0": "null", "t": "null", "T": "null", "TRUE": "null", "yes": "null",
+	} {
+		if got := toJSON(t, constraintBool(&ast.Value{Kind: ast.StringValue, Raw: raw})); got != want {
+			t.Errorf("constraintBool(%s) = %s, want %s", raw, got, want)
+		}
+	}
+}
+
+func TestArgumentConstraints(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+directive @constraint(minLength: Int, max: Int) on ARGUMENT_DEFINITION | INPUT_FIELD_DEFINITION
+type User { id: ID! }
+type Query { search(q: String! @constraint(minLength: 3), limit: Int @constraint(max: 100)): [User!]! }
+type Mutation { rename(name: String! @constraint(minLength: 1)): User }
+`)
+	params := operation(t, doc, "get", "/search").Parameters
+	if got, want := toJSON(t, params[0].Schema), `{"type":"string","minLength":3}`; got != want {
+		t.Errorf("q = %s, want %s", got, want)
+	}
+	if got, want := toJSON(t, params[1].Schema), `{"type":"integer","format":"int32","maximum":100}`; got != want {
+		t.Errorf("limit = %s, want %s", got, want)
+	}
+	body := operation(t, doc, "post", "/rename").RequestBody.Content["application/json"].Schema
+	if got, want := toJSON(t, body.Properties["name"]), `{"type":"string","minLength":1}`; got != want {
+		t.Errorf("name = %s, want %s", got, want)
+	}
+}
+
+func TestNonNegativeConstraint(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+directive @constraint(min: Float, nonNegative: Boolean) on FIELD_DEFINITION
+type Product { id: ID!, stock: Int! @constraint(nonNegative: true), floor: Int! @constraint(min: 5, nonNegative: true) }
+type Query { product(id: ID!): Product }
+`)
+	props := doc.Components.Schemas["Product"].Properties
+	for name, want := range map[string]string{
+		"stock": `{"type":"integer","format":"int32","minimum":0}`,
+		"floor": `{"type":"integer","format":"int32","minimum":5}`,
+	} {
+		if got := toJSON(t, props[name]); got != want {
+			t.Errorf("%s = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestConstraintChoices(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+directive @constraint(oneOf: [String], enum: [String], message: String) on FIELD_DEFINITION
+type Post { id: ID!, status: String! @constraint(oneOf: ["draft", "published"], message: "unknown status"), kind: String @constraint(enum: ["a", "b"]) }
+type Query { post(id: ID!): Post }
+`)
+	props := doc.Components.Schemas["Post"].Properties
+	for name, want := range map[string]string{
+		"status": `{"type":"string","enum":["draft","published"],"x-errorMessage":"unknown status"}`,
+		"kind":   `{"type":"string","enum":["a","b"]}`,
+	} {
+		if got := toJSON(t, props[name]); got != want {
+			t.Errorf("%s = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestConstraintDirectiveAliases(t *testing.T) {
+	config := DefaultConfig()
+	config.ConstraintDirectiveName = "validate"
+	config.ConstraintAliases = map[string]map[string]string{"size": {"min": "minItems", "max": "maxItems"}}
+	doc := convertSDL(t, config, `
+directive @validate(minLength: Int) on FIELD_DEFINITION
+directive @length(min: Int, max: Int) on FIELD_DEFINITION
+directive @range(min: Float, max: Float) on FIELD_DEFINITION
+directive @size(min: Int, max: Int) on FIELD_DEFINITION
+type Person {
+  nickname: String @validate(minLength: 2)
+  name: String @length(min: 1, max: 5)
+  age: Int @range(min: 0, max: 150)
+  tags: [String!] @size(max: 3)
+}
+type Query { person: Person }
+`)
+	properties := doc.Components.Schemas["Person"].Properties
+	for name, want := range map[string]string{
+		"nickname": `{"type":"string","minLength":2}`,
+		"name":     `{"type":"string","minLength":1,"maxLength":5}`,
+		"age":      `{"type":"integer","format":"int32","minimum":0,"maximum":150}`,
+		"tags":     `{"type":"array","items":{"type":"string"},"maxItems":3}`,
+	} {
+		if got := toJSON(t, properties[name]); got != want {
+			t.Errorf("%s = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestConstraintAllowedValues(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+directive @constraint(in: [String], oneOf: [String]) on FIELD_DEFINITION
+type Paint { color: String! @constraint(in: ["red", "green", "blue"]), finish: String @constraint(oneOf: ["matte"]) }
+type Query { paint: Paint }
+`)
+	properties := doc.Components.Schemas["Paint"].Properties
+	if got, want := toJSON(t, properties["color"].Enum), `["red","green","blue"]`; got != want {
+		t.Errorf("color enum = %s, want %s", got, want)
+	}
+	if got, want := toJSON(t, properties["finish"].Enum), `["matte"]`; got != want {
+		t.Errorf("finish enum = %s, want %s", got, want)
+	}
+}
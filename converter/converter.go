@@ -1,8 +1,16 @@
 package converter
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
+	"net/http"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/vektah/gqlparser/v2"
 	"github.com/vektah/gqlparser/v2/ast"
@@ -10,31 +18,232 @@ import (
 
 // Config holds converter configuration
 type Config struct {
-	Title              string
-	Version            string
-	BaseURL            string
-	PathPrefix         string
-	DetectRESTPatterns bool
-	CustomPlurals      map[string]string
+	Title   string `json:"title,omitempty" yaml:"title,omitempty"`
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+	// Title and Version were given explicitly (-title/-version, a config file or
+	// WithTitle/WithVersion), so the schema's @info doesn't replace them
+	TitleSet   bool `json:"-" yaml:"-"`
+	VersionSet bool `json:"-" yaml:"-"`
+	// Info metadata (contact/license blocks are omitted unless a value is set;
+	// a license needs LicenseName, LicenseURL alone is dropped with a warning)
+	ContactName    string `json:"contactName,omitempty" yaml:"contactName,omitempty"`
+	ContactEmail   string `json:"contactEmail,omitempty" yaml:"contactEmail,omitempty"`
+	ContactURL     string `json:"contactUrl,omitempty" yaml:"contactUrl,omitempty"`
+	LicenseName    string `json:"licenseName,omitempty" yaml:"licenseName,omitempty"`
+	LicenseURL     string `json:"licenseUrl,omitempty" yaml:"licenseUrl,omitempty"`
+	TermsOfService string `json:"termsOfService,omitempty" yaml:"termsOfService,omitempty"`
+	// Document-level externalDocs (omitted unless ExternalDocsURL is set)
+	ExternalDocsURL         string   `json:"externalDocsUrl,omitempty" yaml:"externalDocsUrl,omitempty"`
+	ExternalDocsDescription string   `json:"externalDocsDescription,omitempty" yaml:"externalDocsDescription,omitempty"`
+	BaseURL                 string   `json:"baseUrl,omitempty" yaml:"baseUrl,omitempty"`
+	Servers                 []Server `json:"servers,omitempty" yaml:"servers,omitempty"` // Additional servers, listed after BaseURL
+	PathPrefix              string   `json:"pathPrefix,omitempty" yaml:"pathPrefix,omitempty"`
+	PrefixInServerURL       bool     `json:"prefixInServerUrl,omitempty" yaml:"prefixInServerUrl,omitempty"` // Append PathPrefix to each server URL instead of to every path key
+	PathCase                string   `json:"pathCase,omitempty" yaml:"pathCase,omitempty"`                   // Case of path segments derived from field names: "camel" (default), "kebab" or "snake"
+	DetectRESTPatterns      bool     `json:"detectRestPatterns,omitempty" yaml:"detectRestPatterns,omitempty"`
+	// Also document the GraphQL endpoint itself as POST /graphql ({query, variables, operationName})
+	IncludeGraphQLEndpoint bool              `json:"includeGraphqlEndpoint,omitempty" yaml:"includeGraphqlEndpoint,omitempty"`
+	CustomPlurals          map[string]string `json:"customPlurals,omitempty" yaml:"customPlurals,omitempty"`
+	// Irregular singulars, keyed by plural suffix (e.g. "shoes": "shoe", "caches": "cache"), checked
+	// before CustomPlurals and the suffix rules when matching list queries to their resource
+	CustomSingulars map[string]string `json:"customSingulars,omitempty" yaml:"customSingulars,omitempty"`
+	// Rewrites each path the converter generates; nil keeps the built-in paths (library use only)
+	PathBuilder func(PathContext) string `json:"-" yaml:"-"`
 	// Pluralization rules
-	PluralizeSuffixesES  []string // Suffixes that get "es" added (e.g., s, x, z, ch, sh)
-	PluralizeSuffixIES   string   // Suffix that triggers "ies" conversion (default "y")
-	PluralizeDefaultSuffix string // Default suffix to add (default "s")
+	PluralizeSuffixesES    []string `json:"pluralizeSuffixesEs,omitempty" yaml:"pluralizeSuffixesEs,omitempty"`       // Suffixes that get "es" added (e.g., s, x, z, ch, sh)
+	PluralizeSuffixIES     string   `json:"pluralizeSuffixIes,omitempty" yaml:"pluralizeSuffixIes,omitempty"`         // Suffix that triggers "ies" conversion (default "y")
+	PluralizeDefaultSuffix string   `json:"pluralizeDefaultSuffix,omitempty" yaml:"pluralizeDefaultSuffix,omitempty"` // Default suffix to add (default "s")
 	// CRUD operation prefixes for REST pattern detection
-	CRUDPrefixCreate string // Prefix for create operations (default "create")
-	CRUDPrefixUpdate string // Prefix for update operations (default "update")
-	CRUDPrefixDelete string // Prefix for delete operations (default "delete")
+	CRUDPrefixCreate string `json:"crudPrefixCreate,omitempty" yaml:"crudPrefixCreate,omitempty"` // Prefix for create operations (default "create")
+	CRUDPrefixUpdate string `json:"crudPrefixUpdate,omitempty" yaml:"crudPrefixUpdate,omitempty"` // Prefix for update operations (default "update")
+	CRUDPrefixDelete string `json:"crudPrefixDelete,omitempty" yaml:"crudPrefixDelete,omitempty"` // Prefix for delete operations (default "delete")
+	// Also look for create/update/delete fields on Query, for schemas without a real Mutation type
+	DetectMutationsInQuery bool `json:"detectMutationsInQuery,omitempty" yaml:"detectMutationsInQuery,omitempty"`
+	// Names of the field/argument identifying a resource (default ["id"]), e.g. ["uuid", "key"];
+	// the first one a type declares is its identifier
+	ResourceIDFieldNames []string `json:"resourceIdFieldNames,omitempty" yaml:"resourceIdFieldNames,omitempty"`
+	// Words around a list query's name to strip when matching it to its element type
+	// (default ["all", "list"]): allUsers, listUsers and userList all list [User!]! as /users
+	CollectionFieldAffixes []string `json:"collectionFieldAffixes,omitempty" yaml:"collectionFieldAffixes,omitempty"`
+	// Action mutation prefixes mapped to a sub-path of a detected resource's item,
+	// e.g. "archive": "archive" turns archiveUser into POST /users/{id}/archive
+	CRUDCustomActions map[string]string `json:"crudCustomActions,omitempty" yaml:"crudCustomActions,omitempty"`
+	// Resources consolidated regardless of naming heuristics, keyed by resource name (e.g. "person")
+	ForceRESTResources map[string]RESTResourceFields `json:"forceRestResources,omitempty" yaml:"forceRestResources,omitempty"`
+	// Resources never consolidated, keeping their fields as plain query/mutation endpoints
+	DisableRESTResources []string `json:"disableRestResources,omitempty" yaml:"disableRestResources,omitempty"`
+	// Paths for argument-less singleton queries, keyed by field name (e.g. "viewer": "/me")
+	SingletonQueries map[string]string `json:"singletonQueries,omitempty" yaml:"singletonQueries,omitempty"`
+	// Type and operation filters, by exact name or glob (e.g. "Internal*"); empty include lists keep everything
+	IncludeTypes      []string `json:"includeTypes,omitempty" yaml:"includeTypes,omitempty"`
+	ExcludeTypes      []string `json:"excludeTypes,omitempty" yaml:"excludeTypes,omitempty"`
+	IncludeOperations []string `json:"includeOperations,omitempty" yaml:"includeOperations,omitempty"` // Query, mutation and subscription field names
+	ExcludeOperations []string `json:"excludeOperations,omitempty" yaml:"excludeOperations,omitempty"`
+	// Set additionalProperties: false on object and input type schemas and request bodies;
+	// interfaces, unions and types composed with allOf stay permissive
+	StrictObjects bool `json:"strictObjects,omitempty" yaml:"strictObjects,omitempty"`
+	// Response shaping
+	CollapseSingleFieldResponses bool `json:"collapseSingleFieldResponses,omitempty" yaml:"collapseSingleFieldResponses,omitempty"` // Unwrap single-field object return types to the inner field's schema
+	// Respond with a oneOf over an interface's implementing types, discriminated by __typename
+	InterfaceAsOneOf       bool `json:"interfaceAsOneOf,omitempty" yaml:"interfaceAsOneOf,omitempty"`
+	UnwrapPayloads         bool `json:"unwrapPayloads,omitempty" yaml:"unwrapPayloads,omitempty"`                 // Respond with the resource for REST create/update mutations returning a *Payload wrapper
+	MinimalRequestExamples bool `json:"minimalRequestExamples,omitempty" yaml:"minimalRequestExamples,omitempty"` // Give each request body an example holding only its required fields
+	// Media types of JSON request and response bodies (default ["application/json"]), e.g.
+	// ["application/json", "application/xml"]; each gets the same schema. SSE streams keep text/event-stream.
+	ResponseContentTypes []string `json:"responseContentTypes,omitempty" yaml:"responseContentTypes,omitempty"`
+	// 204 responses carry no content; this emits an empty content: {} for gateways that require one
+	EmitContentTypeForEmptyResponses bool `json:"emitContentTypeForEmptyResponses,omitempty" yaml:"emitContentTypeForEmptyResponses,omitempty"`
+	// Respond to REST list and sub-resource endpoints with a $ref to a {Type}List component
+	// ({items: [...]}) instead of an inline array
+	NamedCollectionSchemas bool `json:"namedCollectionSchemas,omitempty" yaml:"namedCollectionSchemas,omitempty"`
+	// Paginate REST list endpoints: "none" (default), "offset" (limit/offset params, {data, total}
+	// response) or "cursor" (limit/cursor params, {data, nextCursor} response)
+	PaginationStyle string `json:"paginationStyle,omitempty" yaml:"paginationStyle,omitempty"`
+	// Serialization of list query parameters: "form-explode" (default, ?tag=a&tag=b), "form-csv"
+	// (?tag=a,b), "spaceDelimited" or "pipeDelimited" (?tag=a|b)
+	ArrayParamStyle string `json:"arrayParamStyle,omitempty" yaml:"arrayParamStyle,omitempty"`
+	// Give REST collection and item paths HEAD (mirroring GET) and OPTIONS (listing the allowed methods) operations
+	GenerateHead    bool `json:"generateHead,omitempty" yaml:"generateHead,omitempty"`
+	GenerateOptions bool `json:"generateOptions,omitempty" yaml:"generateOptions,omitempty"`
+	// Document a CORS preflight OPTIONS operation, responding 204 with Access-Control-Allow-* headers,
+	// on every path that has another operation
+	DocumentCORS bool `json:"documentCors,omitempty" yaml:"documentCors,omitempty"`
+	// REST updates are emitted as PATCH when their input has no required fields; this forces PATCH for all
+	UpdateUsesPatch bool `json:"updateUsesPatch,omitempty" yaml:"updateUsesPatch,omitempty"`
+	// Move the ID arguments of an unconsolidated mutation that also takes other input out of its
+	// body: approveOrder(id: ID!, note: String) becomes POST /approveOrder/{id} with body {note}
+	SplitHybridMutations bool `json:"splitHybridMutations,omitempty" yaml:"splitHybridMutations,omitempty"`
+	// OpenAPI output
+	OpenAPIVersion    string     `json:"openApiVersion,omitempty" yaml:"openApiVersion,omitempty"`       // OpenAPI version to emit: "3.0.0" (default) or "3.1.0"
+	NullableAsOneOf   bool       `json:"nullableAsOneOf,omitempty" yaml:"nullableAsOneOf,omitempty"`     // In 3.1, express nullable fields as oneOf with a {type: "null"} branch
+	TagGroups         []TagGroup `json:"tagGroups,omitempty" yaml:"tagGroups,omitempty"`                 // Emitted as the x-tagGroups extension (default: "Resources" and "Operations")
+	FooterAsExtension bool       `json:"footerAsExtension,omitempty" yaml:"footerAsExtension,omitempty"` // Emit the "Converted from GraphQL" footer as x-generated-by instead of in info.description
+	OmitOperationIds  bool       `json:"omitOperationIds,omitempty" yaml:"omitOperationIds,omitempty"`   // Leave operationId unset on every operation
+	OmitFooter        bool       `json:"omitFooter,omitempty" yaml:"omitFooter,omitempty"`               // Leave the "Converted from GraphQL" footer out altogether
+	// operationId template with {method}, {resource}, {field} and {type} placeholders (e.g. "{method}_{resource}");
+	// empty keeps the built-in names (listUsers, getUser, createUser, ...)
+	OperationIDTemplate string `json:"operationIdTemplate,omitempty" yaml:"operationIdTemplate,omitempty"`
+	// How subscription endpoints deliver events: "sse" (default, text/event-stream) or
+	// "websocket" (a 101 Switching Protocols upgrade)
+	SubscriptionTransport string `json:"subscriptionTransport,omitempty" yaml:"subscriptionTransport,omitempty"`
+	// Nesting limits
+	MaxDepth int `json:"maxDepth,omitempty" yaml:"maxDepth,omitempty"` // Maximum sub-resource nesting depth (default 1: /{plural}/{id}/{field})
+	// Embed referenced object schemas inline instead of as {field}Id strings, MaxEmbedDepth
+	// (default 1) references deep; deeper references, including cyclic ones, stay IDs
+	EmbedObjectReferences bool `json:"embedObjectReferences,omitempty" yaml:"embedObjectReferences,omitempty"`
+	MaxEmbedDepth         int  `json:"maxEmbedDepth,omitempty" yaml:"maxEmbedDepth,omitempty"`
+	// Property annotations
+	AnnotateReadWriteOnly    bool   `json:"annotateReadWriteOnly,omitempty" yaml:"annotateReadWriteOnly,omitempty"`       // Mark object type properties readOnly and input type properties writeOnly
+	NonNullStringsMinLength1 bool   `json:"nonNullStringsMinLength1,omitempty" yaml:"nonNullStringsMinLength1,omitempty"` // Set minLength: 1 on non-null String and ID fields and arguments without an explicit minLength
+	StructuredEnumMetadata   bool   `json:"structuredEnumMetadata,omitempty" yaml:"structuredEnumMetadata,omitempty"`     // Emit x-enum-metadata with each enum value's description and deprecation
+	TreatIDAsInteger         bool   `json:"treatIdAsInteger,omitempty" yaml:"treatIdAsInteger,omitempty"`                 // Map ID to {type: integer, format: int64} instead of string, including id path parameters
+	IntFormat                string `json:"intFormat,omitempty" yaml:"intFormat,omitempty"`                               // Format for Int: "int32" (default, per the GraphQL spec) or "int64"
+	IDFormat                 string `json:"idFormat,omitempty" yaml:"idFormat,omitempty"`                                 // Shape of ID values, including id path parameters: "" (plain string), "uuid" or "int64" (as TreatIDAsInteger)
+	// Formats for @specifiedBy URLs containing a fragment (e.g. "rfc3339#section-5.6": "date"),
+	// checked before the built-in uuid/date-time/date/email/uri/int64 table
+	SpecifiedByFormats map[string]string `json:"specifiedByFormats,omitempty" yaml:"specifiedByFormats,omitempty"`
+	// Directives surfaced as vendor extensions, keyed by directive name (e.g. "rateLimit": "x-rate-limit").
+	// On an operation field the extension goes on the operation, on a type field on the property.
+	DirectiveExtensions map[string]string `json:"directiveExtensions,omitempty" yaml:"directiveExtensions,omitempty"`
+	// Scalars that hold maps, keyed by scalar name, each with the type of its values: "any" (or "")
+	// for a free-form object, or a JSON type, e.g. {"JSON": "any", "StringMap": "string"}
+	MapScalars map[string]string `json:"mapScalars,omitempty" yaml:"mapScalars,omitempty"`
+	// Emit enums as {type: string, enum: [...]} on each property and parameter instead of a
+	// $ref, for generators that mishandle enum refs; enum components left unreferenced are dropped
+	InlineEnums bool `json:"inlineEnums,omitempty" yaml:"inlineEnums,omitempty"`
+	// Types of custom scalars, keyed by scalar name, as "type" or "type:format"
+	// (e.g. {"Money": "string:decimal", "Long": "string"}); these win over the built-in
+	// BigInt/Long/Int64 (integer:int64), Decimal (number), Base64/Bytes (string:byte) and
+	// Binary/Upload (string:binary) recognition
+	ScalarMappings map[string]string `json:"scalarMappings,omitempty" yaml:"scalarMappings,omitempty"`
+	// Format overrides for specific fields, keyed by "Type.field" (e.g. "User.avatar": "uri")
+	FieldFormats map[string]string `json:"fieldFormats,omitempty" yaml:"fieldFormats,omitempty"`
+	// Treat every field as required unless marked @optional, for schemas that declare everything nullable
+	RequiredByDefault bool `json:"requiredByDefault,omitempty" yaml:"requiredByDefault,omitempty"`
+	// Component naming, e.g. prefix "V2" turns User into V2User (applied to keys and $refs)
+	SchemaNamePrefix string            `json:"schemaNamePrefix,omitempty" yaml:"schemaNamePrefix,omitempty"`
+	SchemaNameSuffix string            `json:"schemaNameSuffix,omitempty" yaml:"schemaNameSuffix,omitempty"`
+	SchemaNameMap    map[string]string `json:"schemaNameMap,omitempty" yaml:"schemaNameMap,omitempty"` // Renames specific GraphQL types (e.g. "Schema": "SchemaDefinition") before prefix/suffix
+	// Directive carrying validation constraints (default "constraint")
+	ConstraintDirectiveName string `json:"constraintDirectiveName,omitempty" yaml:"constraintDirectiveName,omitempty"`
+	// Further validation directives, mapping each argument to a constraint argument
+	// (e.g. "size": {"min": "minItems", "max": "maxItems"}); @length and @range are built in
+	ConstraintAliases map[string]map[string]string `json:"constraintAliases,omitempty" yaml:"constraintAliases,omitempty"`
+	// Directive marking input objects that accept exactly one field (default "oneOf")
+	OneOfInputDirective string `json:"oneOfInputDirective,omitempty" yaml:"oneOfInputDirective,omitempty"`
+	// Directive marking query/mutation/subscription fields that get no endpoint (default "internal")
+	InternalDirective string `json:"internalDirective,omitempty" yaml:"internalDirective,omitempty"`
+	// Repeatable directive adding a request header to a field's operation (default "header"),
+	// e.g. @header(name: "X-Tenant-ID", required: true, description: "...")
+	HeaderDirectiveName string `json:"headerDirectiveName,omitempty" yaml:"headerDirectiveName,omitempty"`
+	// Security
+	AuthDirective      string `json:"authDirective,omitempty" yaml:"authDirective,omitempty"`           // Directive marking protected fields (e.g. "auth", "hasRole"); empty disables
+	SecuritySchemeType string `json:"securitySchemeType,omitempty" yaml:"securitySchemeType,omitempty"` // Security scheme for protected fields: "bearer" (default), "basic" or "apiKey"
+	// Document-wide security requirement (e.g. {{"bearerAuth": {}}}); fields marked @public opt out
+	DefaultSecurity SecurityRequirements `json:"defaultSecurity,omitempty" yaml:"defaultSecurity,omitempty"`
+}
+
+// PathContext describes a path the converter is about to use, for Config.PathBuilder
+type PathContext struct {
+	Kind     string // "collection", "item", "subresource", "query", "mutation", "subscription" or "rest" (@rest directive)
+	Resource string // REST resource (e.g. "user") of collection and item paths, parent type of subresource paths
+	Field    string // GraphQL field behind the path, if any
+	Path     string // Path the converter would use, prefix included (an item path extends the built collection path)
+}
+
+// RESTResourceFields names the GraphQL fields backing a forced REST resource
+type RESTResourceFields struct {
+	Plural string `json:"plural,omitempty" yaml:"plural,omitempty"` // Collection path segment (default: pluralized resource name)
+	List   string `json:"list,omitempty" yaml:"list,omitempty"`     // Query returning the collection
+	Get    string `json:"get,omitempty" yaml:"get,omitempty"`       // Query fetching one item by id
+	Create string `json:"create,omitempty" yaml:"create,omitempty"` // Mutation creating an item
+	Update string `json:"update,omitempty" yaml:"update,omitempty"` // Mutation updating an item
+	Delete string `json:"delete,omitempty" yaml:"delete,omitempty"` // Mutation deleting an item
 }
 
 // Converter converts GraphQL schemas to OpenAPI
 type Converter struct {
-	config Config
-	schema *ast.Schema
-	doc    *OpenAPIDocument
+	config     Config
+	schema     *ast.Schema
+	doc        *OpenAPIDocument
+	warnings   []string
+	detections []DetectionReport
+	inputNames map[string]string // input object -> component key, where renamed to avoid an output type's
 }
 
-// New creates a new converter
+// New creates a new converter. Unset pluralization rules, CRUD prefixes,
+// resource id field names, collection affixes and content types take the CLI
+// defaults. REST detection is still off unless DetectRESTPatterns is set; start
+// from DefaultConfig (or use NewWithOptions) to get the CLI's behaviour.
 func New(config Config) *Converter {
+	defaults := DefaultConfig()
+	if config.PluralizeSuffixesES == nil {
+		config.PluralizeSuffixesES = defaults.PluralizeSuffixesES
+	}
+	if config.PluralizeSuffixIES == "" {
+		config.PluralizeSuffixIES = defaults.PluralizeSuffixIES
+	}
+	if config.PluralizeDefaultSuffix == "" {
+		config.PluralizeDefaultSuffix = defaults.PluralizeDefaultSuffix
+	}
+	if config.CRUDPrefixCreate == "" {
+		config.CRUDPrefixCreate = defaults.CRUDPrefixCreate
+	}
+	if config.CRUDPrefixUpdate == "" {
+		config.CRUDPrefixUpdate = defaults.CRUDPrefixUpdate
+	}
+	if config.CRUDPrefixDelete == "" {
+		config.CRUDPrefixDelete = defaults.CRUDPrefixDelete
+	}
+	if config.CollectionFieldAffixes == nil {
+		config.CollectionFieldAffixes = defaults.CollectionFieldAffixes
+	}
+	if len(config.ResourceIDFieldNames) == 0 {
+		config.ResourceIDFieldNames = defaults.ResourceIDFieldNames
+	}
+	if len(config.ResponseContentTypes) == 0 {
+		config.ResponseContentTypes = defaults.ResponseContentTypes
+	}
 	return &Converter{
 		config: config,
 	}
@@ -42,26 +251,57 @@ func New(config Config) *Converter {
 
 // Convert converts a GraphQL schema to OpenAPI
 func (c *Converter) Convert(schemaSource string) (*OpenAPIDocument, error) {
+	if err := c.build(schemaSource); err != nil {
+		return nil, err
+	}
+	for _, path := range sortedPaths(c.doc) {
+		c.finishPathItem(c.doc.Paths[path])
+	}
+	return c.doc, nil
+}
+
+// build converts schemaSource into c.doc, all but the per-operation examples
+// and content types that finishPathItem adds to each path item
+func (c *Converter) build(schemaSource string) error {
 	// Parse GraphQL schema
 	schema, err := gqlparser.LoadSchema(&ast.Source{
 		Input: schemaSource,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse GraphQL schema: %w", err)
+		return fmt.Errorf("failed to parse GraphQL schema: %w", err)
 	}
 
 	c.schema = schema
+	c.warnings = nil
+	c.detections = nil
+	c.inputNames = nil // namespaceInputs goes through schemaName, so start without renames
+	c.inputNames = c.namespaceInputs()
+	if err := c.checkReturnsDirectives(); err != nil {
+		return err
+	}
 
 	// Extract schema description (appears before the first type definition)
 	schemaDesc := c.extractSchemaDescription(schemaSource)
 
-	// Use first line as title if available, rest as description
+	// schema @info(title: "Orders API", version: "3.2.0") stands in for a title
+	// and version that weren't given explicitly
 	title := c.config.Title
+	version := c.config.Version
+	if info := schema.SchemaDirectives.ForName("info"); info != nil {
+		if arg := info.Arguments.ForName("title"); arg != nil && arg.Value.Raw != "" && !c.config.TitleSet {
+			title = arg.Value.Raw
+		}
+		if arg := info.Arguments.ForName("version"); arg != nil && arg.Value.Raw != "" && !c.config.VersionSet {
+			version = arg.Value.Raw
+		}
+	}
+
+	// Use first line as title if available, rest as description
 	description := schemaDesc
 	if schemaDesc != "" {
 		lines := strings.Split(schemaDesc, "\n")
 		firstLine := strings.TrimSpace(lines[0])
-		if firstLine != "" && c.config.Title == "Converted from GraphQL" {
+		if firstLine != "" && title == "Converted from GraphQL" && !c.config.TitleSet {
 			// Use first line as title
 			title = firstLine
 			// Rest becomes description
@@ -73,30 +313,89 @@ func (c *Converter) Convert(schemaSource string) (*OpenAPIDocument, error) {
 		}
 	}
 
-	// Add footer to description
-	footer := fmt.Sprintf("Converted from GraphQL (%s)", c.config.Version)
-	if description != "" {
+	// Add footer to description, or emit it as x-generated-by
+	footer := fmt.Sprintf("Converted from GraphQL (%s)", version)
+	generatedBy := ""
+	if c.config.OmitFooter {
+		// An empty description is omitted from info
+	} else if c.config.FooterAsExtension {
+		generatedBy = footer
+	} else if description != "" {
 		description = description + "\n\n---\n\n" + footer
 	} else {
 		description = footer
 	}
 
 	c.doc = &OpenAPIDocument{
-		OpenAPI: "3.0.0",
+		OpenAPI: c.openAPIVersion(),
 		Info: Info{
 			Title:       title,
-			Version:     c.config.Version,
+			Version:     version,
 			Description: description,
 		},
-		Paths:      make(map[string]*PathItem),
+		Paths: make(map[string]*PathItem),
 		Components: &Components{
 			Schemas: make(map[string]*Schema),
 		},
+		GeneratedBy: generatedBy,
+	}
+
+	c.doc.Info.TermsOfService = c.config.TermsOfService
+	if c.config.ContactName != "" || c.config.ContactEmail != "" || c.config.ContactURL != "" {
+		c.doc.Info.Contact = &Contact{
+			Name:  c.config.ContactName,
+			Email: c.config.ContactEmail,
+			URL:   c.config.ContactURL,
+		}
+	}
+	if c.config.LicenseName != "" {
+		c.doc.Info.License = &License{
+			Name: c.config.LicenseName,
+			URL:  c.config.LicenseURL,
+		}
+	} else if c.config.LicenseURL != "" {
+		// OpenAPI requires a license name, so a bare URL can't stand alone
+		c.warn("license URL %s has no license name, leaving the license out", c.config.LicenseURL)
+	}
+
+	if c.config.ExternalDocsURL != "" {
+		c.doc.ExternalDocs = &ExternalDocumentation{
+			Description: c.config.ExternalDocsDescription,
+			URL:         c.config.ExternalDocsURL,
+		}
 	}
 
 	if c.config.BaseURL != "" {
 		c.doc.Servers = []Server{{URL: c.config.BaseURL}}
 	}
+	for _, server := range c.config.Servers {
+		if c.config.BaseURL != "" && server.URL == c.config.BaseURL {
+			// BaseURL already listed; keep the description from Servers
+			c.doc.Servers[0].Description = server.Description
+			continue
+		}
+		c.doc.Servers = append(c.doc.Servers, server)
+	}
+
+	if len(c.config.DefaultSecurity) > 0 {
+		c.doc.Security = c.config.DefaultSecurity
+		// Define the configured scheme when the default refers to it
+		name, scheme := c.securityScheme()
+		for _, requirement := range c.config.DefaultSecurity {
+			if _, ok := requirement[name]; ok {
+				c.addSecurityScheme(name, scheme)
+			}
+		}
+	}
+	if c.config.PrefixInServerURL && c.config.PathPrefix != "" {
+		if len(c.doc.Servers) == 0 {
+			c.doc.Servers = []Server{{URL: c.config.PathPrefix}}
+		} else {
+			for i := range c.doc.Servers {
+				c.doc.Servers[i].URL = strings.TrimSuffix(c.doc.Servers[i].URL, "/") + c.config.PathPrefix
+			}
+		}
+	}
 
 	// Detect REST patterns if enabled
 	restPatterns := make(map[string]*RESTPattern)
@@ -106,7 +405,7 @@ func (c *Converter) Convert(schemaSource string) (*OpenAPIDocument, error) {
 
 	// Convert types to schemas
 	for _, typeDef := range schema.Types {
-		if isBuiltInType(typeDef.Name) {
+		if isBuiltInType(typeDef.Name) || !c.typeIncluded(typeDef.Name) {
 			continue
 		}
 		switch typeDef.Kind {
@@ -125,21 +424,147 @@ func (c *Converter) Convert(schemaSource string) (*OpenAPIDocument, error) {
 	if schema.Query != nil {
 		c.convertQueries(schema.Query, restPatterns)
 	}
-	if schema.Mutation != nil {
+	if schema.Mutation != nil || c.config.DetectMutationsInQuery {
 		c.convertMutations(schema.Mutation, restPatterns)
 	}
 	if schema.Subscription != nil {
 		c.convertSubscriptions(schema.Subscription)
 	}
+	if c.config.IncludeGraphQLEndpoint {
+		c.addGraphQLEndpoint()
+	}
 
-	return c.doc, nil
+	c.doc.Tags = c.buildTags(restPatterns)
+	if len(c.config.TagGroups) > 0 {
+		c.doc.TagGroups = c.config.TagGroups
+	} else {
+		c.doc.TagGroups = defaultTagGroups(c.doc.Tags, restPatterns)
+	}
+	c.addProbeOperations(restPatterns)
+	c.addCORSPreflight()
+	c.addAuthResponses()
+	if c.config.InlineEnums {
+		c.pruneInlinedEnums()
+	}
+	if c.config.OmitOperationIds {
+		c.omitOperationIDs()
+	} else {
+		c.dedupeOperationIDs()
+	}
+	c.hoistPathParameters()
+
+	for _, item := range c.doc.Webhooks {
+		for _, po := range pathOperations(item) {
+			c.addContentTypes(po.Operation)
+		}
+	}
+	for _, response := range c.doc.Components.Responses {
+		c.expandContentTypes(response.Content)
+	}
+	return nil
+}
+
+// finishPathItem gives the operations of item their request and response
+// examples and extra content types. Nothing else reads them, so each path can
+// be finished on its own, just before it is written.
+func (c *Converter) finishPathItem(item *PathItem) {
+	for _, po := range pathOperations(item) {
+		if c.config.MinimalRequestExamples {
+			c.addRequestExample(po.Operation)
+		}
+		c.addFieldExamples(po.Operation)
+		c.addContentTypes(po.Operation)
+	}
+}
+
+// ConvertToMap returns the document produced by the last Convert call as a
+// generic map, round-tripped through JSON so omitempty rules are reflected
+func (c *Converter) ConvertToMap() (map[string]interface{}, error) {
+	if c.doc == nil {
+		return nil, fmt.Errorf("no document: call Convert first")
+	}
+
+	data, err := json.Marshal(c.doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal document: %w", err)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal document: %w", err)
+	}
+	return result, nil
+}
+
+// Warnings returns the warnings reported by the last Convert call
+func (c *Converter) Warnings() []string {
+	return c.warnings
+}
+
+func (c *Converter) warn(format string, args ...interface{}) {
+	c.warnings = append(c.warnings, fmt.Sprintf(format, args...))
 }
 
 type RESTPattern struct {
 	Resource   string // e.g., "user"
 	Plural     string // e.g., "users"
 	Type       *ast.Definition
-	Operations map[string]bool // list, get, create, update, delete
+	Operations map[string]bool   // list, get, create, update, delete
+	Fields     map[string]string // operation -> GraphQL field name (e.g., "list" -> "users")
+	Actions    map[string]string // item sub-path -> GraphQL field name (e.g., "archive" -> "archiveUser")
+	IDParam    string            // item path parameter, named after the get query's argument (default "id")
+	Path       string            // collection path from the type's @resource(path: ...), used as given
+}
+
+// DetectionReport records the REST pattern detection decision for one candidate resource
+type DetectionReport struct {
+	Resource   string            `json:"resource"`
+	Plural     string            `json:"plural"`
+	Path       string            `json:"path"` // collection path, including any prefix
+	Type       string            `json:"type,omitempty"`
+	Operations map[string]string `json:"operations"` // operation -> GraphQL field name
+	Status     string            `json:"status"`     // "consolidated" or "filtered"
+	Reason     string            `json:"reason,omitempty"`
+}
+
+// DetectionReport returns the REST pattern detection decisions of the last Convert call
+func (c *Converter) DetectionReport() []DetectionReport {
+	return c.detections
+}
+
+func (c *Converter) addPatternOperation(patterns map[string]*RESTPattern, resource, plural, operation, fieldName string) *RESTPattern {
+	if patterns[resource] == nil {
+		_, path := c.resourceOverride(c.capitalize(resource))
+		patterns[resource] = &RESTPattern{
+			Resource:   resource,
+			Plural:     plural,
+			Operations: make(map[string]bool),
+			Fields:     make(map[string]string),
+			Path:       path,
+		}
+	}
+	patterns[resource].Operations[operation] = true
+	patterns[resource].Fields[operation] = fieldName
+	return patterns[resource]
+}
+
+// collectionResource returns the resource a list query named like allUsers,
+// listUsers or userList (per Config.CollectionFieldAffixes) lists, when what
+// remains of the name is typeName or its plural; otherwise ""
+func (c *Converter) collectionResource(fieldName, typeName string) string {
+	resource := c.uncapitalize(typeName)
+	for _, affix := range c.config.CollectionFieldAffixes {
+		rest := ""
+		if prefixed, ok := strings.CutPrefix(fieldName, affix); ok && prefixed != "" {
+			rest = c.uncapitalize(prefixed)
+		} else if suffixed, ok := strings.CutSuffix(fieldName, c.capitalize(affix)); ok {
+			rest = suffixed
+		}
+		if rest != "" && (rest == resource || rest == c.pluralize(resource)) {
+			return resource
+		}
+	}
+	return ""
 }
 
 func (c *Converter) detectRESTPatterns() map[string]*RESTPattern {
@@ -148,91 +573,238 @@ func (c *Converter) detectRESTPatterns() map[string]*RESTPattern {
 	// First pass: find list operations (e.g., users: [User!]!)
 	if c.schema.Query != nil {
 		for _, field := range c.schema.Query.Fields {
+			// Fields with an explicit @rest mapping are never consolidated
+			if field.Directives.ForName("rest") != nil || !c.fieldIncluded(field) {
+				continue
+			}
+
 			if field.Type.Elem != nil && field.Type.Elem.NamedType != "" {
 				// This is a list type
 				typeName := field.Type.Elem.NamedType
 				singular := c.singularize(field.Name)
 
-				if singular != field.Name {
-					// field.Name is plural
-					if patterns[singular] == nil {
-						patterns[singular] = &RESTPattern{
-							Resource:   singular,
-							Plural:     field.Name,
-							Operations: make(map[string]bool),
-						}
+				if name, _ := c.resourceOverride(typeName); name != "" {
+					// @resource names the collection, so no pluralization guesswork
+					if field.Name == name {
+						pattern := c.addPatternOperation(patterns, c.uncapitalize(typeName), name, "list", field.Name)
+						pattern.Plural = name
+						pattern.Type = c.schema.Types[typeName]
 					}
-					patterns[singular].Operations["list"] = true
-					patterns[singular].Type = c.schema.Types[typeName]
+				} else if resource := c.collectionResource(field.Name, typeName); resource != "" {
+					// allUsers/userList: the element type names the resource
+					plural := c.resourcePlural(resource)
+					pattern := c.addPatternOperation(patterns, resource, plural, "list", field.Name)
+					pattern.Type = c.schema.Types[typeName]
+				} else if singular != field.Name {
+					// field.Name is plural
+					pattern := c.addPatternOperation(patterns, singular, field.Name, "list", field.Name)
+					pattern.Plural = field.Name
+					pattern.Type = c.schema.Types[typeName]
 				}
 			}
 
-			// Check for get by ID (e.g., user(id: ID!): User)
-			if len(field.Arguments) == 1 && field.Arguments[0].Name == "id" {
-				typeName := field.Type.Name()
+			// Check for get by ID (e.g., user(id: ID!): User or user(userId: ID!): User)
+			// A nullable id can be left out, so it can't be a path segment
+			typeName := field.Type.Name()
+			if len(field.Arguments) == 1 && (c.isItemIDArgument(field.Arguments[0]) || field.Arguments[0].Name == c.keyField(typeName)) && field.Arguments[0].Type.NonNull {
 				if field.Name == c.singularize(typeName) || strings.ToLower(field.Name) == strings.ToLower(typeName) {
-					if patterns[field.Name] == nil {
-						patterns[field.Name] = &RESTPattern{
-							Resource:   field.Name,
-							Plural:     c.pluralize(field.Name),
-							Operations: make(map[string]bool),
-						}
-					}
-					patterns[field.Name].Operations["get"] = true
-					patterns[field.Name].Type = c.schema.Types[typeName]
+					pattern := c.addPatternOperation(patterns, field.Name, c.resourcePlural(field.Name), "get", field.Name)
+					pattern.Type = c.schema.Types[typeName]
+					pattern.IDParam = field.Arguments[0].Name
 				}
 			}
 		}
 	}
 
 	// Second pass: find mutations
-	if c.schema.Mutation != nil {
-		for _, field := range c.schema.Mutation.Fields {
+	// Mutations without a matching query are still recorded so the report can explain them
+	mutationRoots := []*ast.Definition{c.schema.Mutation}
+	if c.config.DetectMutationsInQuery {
+		mutationRoots = append(mutationRoots, c.schema.Query)
+	}
+	for _, root := range mutationRoots {
+		if root == nil {
+			continue
+		}
+		for _, field := range root.Fields {
+			if field.Directives.ForName("rest") != nil || !c.fieldIncluded(field) {
+				continue
+			}
+			if root == c.schema.Query && c.schema.Mutation != nil && c.schema.Mutation.Fields.ForName(field.Name) != nil {
+				continue // The real mutation of the same name wins
+			}
 			name := field.Name
 
 			// Check for create{Resource}
 			if c.config.CRUDPrefixCreate != "" && strings.HasPrefix(name, c.config.CRUDPrefixCreate) {
 				resource := c.uncapitalize(strings.TrimPrefix(name, c.config.CRUDPrefixCreate))
-				if patterns[resource] != nil {
-					patterns[resource].Operations["create"] = true
-				}
+				c.addPatternOperation(patterns, resource, c.resourcePlural(resource), "create", name)
 			}
 
 			// Check for update{Resource}
 			if c.config.CRUDPrefixUpdate != "" && strings.HasPrefix(name, c.config.CRUDPrefixUpdate) {
 				resource := c.uncapitalize(strings.TrimPrefix(name, c.config.CRUDPrefixUpdate))
-				if patterns[resource] != nil {
-					patterns[resource].Operations["update"] = true
-				}
+				c.addPatternOperation(patterns, resource, c.resourcePlural(resource), "update", name)
 			}
 
 			// Check for delete{Resource}
 			if c.config.CRUDPrefixDelete != "" && strings.HasPrefix(name, c.config.CRUDPrefixDelete) {
 				resource := c.uncapitalize(strings.TrimPrefix(name, c.config.CRUDPrefixDelete))
-				if patterns[resource] != nil {
-					patterns[resource].Operations["delete"] = true
+				c.addPatternOperation(patterns, resource, c.resourcePlural(resource), "delete", name)
+			}
+		}
+	}
+
+	// Forced resources are consolidated from the configured field names
+	forced := make(map[string]bool)
+	for resource, fields := range c.config.ForceRESTResources {
+		pattern := c.forceRESTPattern(resource, fields)
+		if pattern == nil {
+			c.warn("forced REST resource '%s' has no list or get query to derive its type from", resource)
+			continue
+		}
+		patterns[resource] = pattern
+		forced[resource] = true
+	}
+
+	// Action mutations (e.g. archiveUser) attach to resources found above
+	if len(c.config.CRUDCustomActions) > 0 {
+		prefixes := make([]string, 0, len(c.config.CRUDCustomActions))
+		for prefix := range c.config.CRUDCustomActions {
+			prefixes = append(prefixes, prefix)
+		}
+		sort.Strings(prefixes)
+		var fields ast.FieldList
+		for _, root := range mutationRoots {
+			if root != nil {
+				fields = append(fields, root.Fields...)
+			}
+		}
+		for _, field := range fields {
+			if field.Directives.ForName("rest") != nil || !c.fieldIncluded(field) || c.mutationField(field.Name) != field {
+				continue
+			}
+			for _, prefix := range prefixes {
+				if prefix == "" || !strings.HasPrefix(field.Name, prefix) {
+					continue
+				}
+				pattern := patterns[c.uncapitalize(strings.TrimPrefix(field.Name, prefix))]
+				if pattern == nil {
+					continue
+				}
+				if pattern.Actions == nil {
+					pattern.Actions = make(map[string]string)
 				}
+				pattern.Actions[c.config.CRUDCustomActions[prefix]] = field.Name
 			}
 		}
 	}
 
+	disabled := make(map[string]bool)
+	for _, resource := range c.config.DisableRESTResources {
+		disabled[resource] = true
+	}
+
 	// Filter: only keep patterns that have at least list + create
 	filtered := make(map[string]*RESTPattern)
-	for resource, pattern := range patterns {
-		if pattern.Operations["list"] && pattern.Operations["create"] {
+	for _, resource := range sortedResources(patterns) {
+		pattern := patterns[resource]
+		report := DetectionReport{
+			Resource:   resource,
+			Plural:     pattern.Plural,
+			Path:       c.collectionPath(pattern),
+			Operations: pattern.Fields,
+			Status:     "consolidated",
+		}
+		if pattern.Type != nil {
+			report.Type = pattern.Type.Name
+		}
+
+		if disabled[resource] {
+			report.Status = "filtered"
+			report.Reason = "disabled by configuration"
+		} else if pattern.Type != nil && !c.typeIncluded(pattern.Type.Name) {
+			report.Status = "filtered"
+			report.Reason = "type excluded by configuration"
+		} else if forced[resource] {
+			filtered[resource] = pattern
+			report.Reason = "forced by configuration"
+		} else if pattern.Operations["list"] && pattern.Operations["create"] {
 			filtered[resource] = pattern
-			operationNames := []string{}
-			for op := range pattern.Operations {
-				operationNames = append(operationNames, op)
+		} else {
+			missing := []string{}
+			if !pattern.Operations["list"] {
+				missing = append(missing, fmt.Sprintf("list query (%s: [%s])", pattern.Plural, c.capitalize(resource)))
+			}
+			if !pattern.Operations["create"] {
+				missing = append(missing, fmt.Sprintf("create mutation (%s%s)", c.config.CRUDPrefixCreate, c.capitalize(resource)))
 			}
-			fmt.Printf("Detected REST pattern '%s': consolidated %d operations → /%s\n",
-				resource, len(pattern.Operations), pattern.Plural)
+			report.Status = "filtered"
+			report.Reason = "missing " + strings.Join(missing, " and ")
 		}
+		c.detections = append(c.detections, report)
 	}
 
 	return filtered
 }
 
+// mutationField returns the mutation field called name, also looking in the
+// Query type under Config.DetectMutationsInQuery
+func (c *Converter) mutationField(name string) *ast.FieldDefinition {
+	if c.schema.Mutation != nil {
+		if field := c.schema.Mutation.Fields.ForName(name); field != nil {
+			return field
+		}
+	}
+	if c.config.DetectMutationsInQuery && c.schema.Query != nil {
+		return c.schema.Query.Fields.ForName(name)
+	}
+	return nil
+}
+
+// forceRESTPattern builds a pattern for a resource listed in Config.ForceRESTResources
+func (c *Converter) forceRESTPattern(resource string, fields RESTResourceFields) *RESTPattern {
+	pattern := &RESTPattern{
+		Resource:   resource,
+		Plural:     fields.Plural,
+		Operations: make(map[string]bool),
+		Fields:     make(map[string]string),
+	}
+	if pattern.Plural == "" {
+		pattern.Plural = c.pluralize(resource)
+	}
+
+	for op, fieldName := range map[string]string{
+		"list": fields.List, "get": fields.Get,
+		"create": fields.Create, "update": fields.Update, "delete": fields.Delete,
+	} {
+		if fieldName == "" {
+			continue
+		}
+		root := c.schema.Mutation
+		if op == "list" || op == "get" {
+			root = c.schema.Query
+		}
+		if root == nil || root.Fields.ForName(fieldName) == nil {
+			c.warn("forced REST resource '%s': %s field '%s' not found", resource, op, fieldName)
+			continue
+		}
+		pattern.Operations[op] = true
+		pattern.Fields[op] = fieldName
+		if op == "list" || op == "get" {
+			pattern.Type = c.schema.Types[root.Fields.ForName(fieldName).Type.Name()]
+		}
+		if args := root.Fields.ForName(fieldName).Arguments; op == "get" && len(args) == 1 {
+			pattern.IDParam = args[0].Name
+		}
+	}
+
+	if pattern.Type == nil {
+		return nil
+	}
+	return pattern
+}
+
 func (c *Converter) convertEnumType(typeDef *ast.Definition) {
 	enumValues := []string{}
 	for _, val := range typeDef.EnumValues {
@@ -248,14 +820,94 @@ func (c *Converter) convertEnumType(typeDef *ast.Definition) {
 		schema.Description = typeDef.Description
 	}
 
-	c.doc.Components.Schemas[typeDef.Name] = schema
+	// OpenAPI enums can't document individual values, so list them in the description
+	if values := enumValueList(typeDef); values != "" {
+		if schema.Description != "" {
+			schema.Description += "\n\n" + values
+		} else {
+			schema.Description = values
+		}
+	}
+
+	if c.config.StructuredEnumMetadata {
+		for _, val := range typeDef.EnumValues {
+			metadata := EnumValueMetadata{
+				Value:       val.Name,
+				Description: normalizeDescription(val.Description),
+			}
+			if deprecated := val.Directives.ForName("deprecated"); deprecated != nil {
+				metadata.Deprecated = true
+				if reason := deprecated.Arguments.ForName("reason"); reason != nil {
+					metadata.DeprecationReason = reason.Value.Raw
+				}
+			}
+			schema.EnumMetadata = append(schema.EnumMetadata, metadata)
+		}
+	}
+
+	schema.Title = typeDef.Name
+	c.doc.Components.Schemas[c.schemaName(typeDef.Name)] = schema
+}
+
+// inlineEnum returns the enum's values as a schema for use sites under Config.InlineEnums
+func inlineEnum(typeDef *ast.Definition) *Schema {
+	schema := &Schema{Type: "string", Enum: []string{}}
+	for _, val := range typeDef.EnumValues {
+		schema.Enum = append(schema.Enum, val.Name)
+	}
+	return schema
+}
+
+// pruneInlinedEnums drops the enum components nothing refers to once
+// Config.InlineEnums has inlined them at their use sites
+func (c *Converter) pruneInlinedEnums() {
+	referenced := make(map[string]bool)
+	for _, ref := range refsIn(c.doc) {
+		referenced[ref] = true
+	}
+	for name, typeDef := range c.schema.Types {
+		key := c.schemaName(name)
+		if typeDef.Kind == ast.Enum && c.doc.Components.Schemas[key] != nil && !referenced["schemas/"+key] {
+			delete(c.doc.Components.Schemas, key)
+		}
+	}
+}
+
+// enumValueList renders an enum's values as a markdown list with their
+// descriptions and deprecations, or "" when no value has either
+func enumValueList(typeDef *ast.Definition) string {
+	documented := false
+	lines := []string{}
+	for _, val := range typeDef.EnumValues {
+		line := "- " + val.Name
+		if val.Description != "" {
+			line += ": " + strings.Join(strings.Fields(val.Description), " ")
+			documented = true
+		}
+		if deprecated := val.Directives.ForName("deprecated"); deprecated != nil {
+			line += " (DEPRECATED"
+			if reason := deprecated.Arguments.ForName("reason"); reason != nil {
+				line += ": " + reason.Value.Raw
+			}
+			line += ")"
+			documented = true
+		}
+		lines = append(lines, line)
+	}
+	if !documented {
+		return ""
+	}
+	return strings.Join(lines, "\n")
 }
 
 func (c *Converter) convertUnionType(typeDef *ast.Definition) {
 	oneOf := []*Schema{}
 	for _, t := range typeDef.Types {
+		if !c.typeIncluded(t) {
+			continue
+		}
 		oneOf = append(oneOf, &Schema{
-			Ref: "#/components/schemas/" + t,
+			Ref: c.schemaRef(t),
 		})
 	}
 
@@ -267,7 +919,8 @@ func (c *Converter) convertUnionType(typeDef *ast.Definition) {
 		schema.Description = typeDef.Description
 	}
 
-	c.doc.Components.Schemas[typeDef.Name] = schema
+	schema.Title = typeDef.Name
+	c.doc.Components.Schemas[c.schemaName(typeDef.Name)] = schema
 }
 
 func (c *Converter) convertInterfaceType(typeDef *ast.Definition) {
@@ -282,16 +935,68 @@ func (c *Converter) convertInterfaceType(typeDef *ast.Definition) {
 		schema.Description = typeDef.Description
 	}
 
-	// Add properties from the interface fields
+	// Add properties from the interface fields; an interface implementing
+	// others (interface Content implements Node) inherits theirs via allOf
+	inherited := c.inheritedFields(typeDef)
 	for _, field := range typeDef.Fields {
+		if inherited[field.Name] {
+			continue
+		}
 		propSchema := c.convertFieldType(field.Type)
 		if field.Description != "" {
 			propSchema.Description = c.addFieldNamePrefix(field.Name, field.Description)
 		}
+		c.applyExample(propSchema, field.Directives)
+		c.applyDefault(propSchema, field.Directives)
+		propSchema.Extensions = c.directiveExtensions(propSchema.Extensions, field.Directives)
+		required := c.isRequiredField(field)
+		if !required && !c.isOpenAPI31() && c.isEnumType(field.Type.Name()) && propSchema.Ref != "" {
+			propSchema = nullableAllOf(propSchema)
+		} else if propSchema.Default != nil {
+			propSchema = c.allOfRef(propSchema)
+		}
 		schema.Properties[field.Name] = propSchema
+
+		// Required like object fields, so implementers agree through allOf
+		if required {
+			schema.Required = append(schema.Required, field.Name)
+		}
+	}
+
+	if len(inherited) > 0 {
+		schema = c.composeInterfaces(typeDef, schema)
+	}
+	schema.Title = typeDef.Name
+	c.doc.Components.Schemas[c.schemaName(typeDef.Name)] = schema
+}
+
+// inheritedFields returns the names of the fields typeDef's included
+// interfaces declare, which it inherits via allOf instead of repeating
+func (c *Converter) inheritedFields(typeDef *ast.Definition) map[string]bool {
+	inherited := make(map[string]bool)
+	for _, name := range typeDef.Interfaces {
+		if iface := c.schema.Types[name]; iface != nil && c.typeIncluded(name) {
+			for _, field := range iface.Fields {
+				inherited[field.Name] = true
+			}
+		}
 	}
+	return inherited
+}
 
-	c.doc.Components.Schemas[typeDef.Name] = schema
+// composeInterfaces returns an allOf of a $ref per included interface of
+// typeDef followed by own, the schema of its own fields
+func (c *Converter) composeInterfaces(typeDef *ast.Definition, own *Schema) *Schema {
+	composed := &Schema{Description: own.Description}
+	for _, name := range typeDef.Interfaces {
+		if !c.typeIncluded(name) {
+			continue
+		}
+		composed.AllOf = append(composed.AllOf, &Schema{Ref: c.schemaRef(name)})
+	}
+	own.Description = ""
+	composed.AllOf = append(composed.AllOf, own)
+	return composed
 }
 
 func (c *Converter) convertType(typeDef *ast.Definition) {
@@ -299,6 +1004,39 @@ func (c *Converter) convertType(typeDef *ast.Definition) {
 		return
 	}
 
+	schema := c.objectSchema(typeDef, 0)
+	schema.Title = typeDef.Name
+	if reason, ok := c.typeDeprecation(typeDef.Name); ok {
+		schema.Deprecated = true
+		if reason != "" && schema.Description != "" {
+			schema.Description += "\n\nDEPRECATED: " + reason
+		} else if reason != "" {
+			schema.Description = "DEPRECATED: " + reason
+		}
+	}
+	c.doc.Components.Schemas[c.schemaName(typeDef.Name)] = schema
+}
+
+// typeDeprecation reports whether the type typeName carries @deprecated (which
+// the schema must redeclare on OBJECT or INPUT_OBJECT), and its reason
+func (c *Converter) typeDeprecation(typeName string) (string, bool) {
+	typeDef := c.schema.Types[typeName]
+	if typeDef == nil {
+		return "", false
+	}
+	deprecated := typeDef.Directives.ForName("deprecated")
+	if deprecated == nil {
+		return "", false
+	}
+	if reason := deprecated.Arguments.ForName("reason"); reason != nil {
+		return reason.Value.Raw, true
+	}
+	return "", true
+}
+
+// objectSchema converts an object or input type's fields into a schema. depth
+// counts the object references embedded so far under Config.EmbedObjectReferences.
+func (c *Converter) objectSchema(typeDef *ast.Definition, depth int) *Schema {
 	schema := &Schema{
 		Type:       "object",
 		Properties: make(map[string]*Schema),
@@ -309,7 +1047,15 @@ func (c *Converter) convertType(typeDef *ast.Definition) {
 		schema.Description = typeDef.Description
 	}
 
+	// Fields declared by implemented interfaces are inherited via allOf
+	inherited := c.inheritedFields(typeDef)
+
+	propertyOrder := []string{}
 	for _, field := range typeDef.Fields {
+		if inherited[field.Name] {
+			continue
+		}
+
 		propSchema := c.convertFieldType(field.Type)
 
 		// Add human-friendly prefix to field description
@@ -333,11 +1079,11 @@ func (c *Converter) convertType(typeDef *ast.Definition) {
 			}
 		}
 
-		// Handle constraint directive
-		constraint := field.Directives.ForName("constraint")
-		if constraint != nil {
-			c.applyConstraints(propSchema, constraint)
-		}
+		// Handle constraint directives
+		c.applyConstraintDirectives(propSchema, field.Directives)
+
+		// Non-null strings must not be empty unless a minLength was given explicitly
+		c.applyNonEmptyString(propSchema, field.Type)
 
 		// Handle specifiedBy directive on the field's type
 		if fieldType := c.schema.Types[field.Type.Name()]; fieldType != nil {
@@ -349,101 +1095,266 @@ func (c *Converter) convertType(typeDef *ast.Definition) {
 			}
 		}
 
+		// Per-field format overrides, e.g. "User.avatar": "uri"
+		if format, ok := c.config.FieldFormats[typeDef.Name+"."+field.Name]; ok {
+			if propSchema.Items != nil {
+				propSchema.Items.Format = format
+			} else {
+				propSchema.Format = format
+			}
+		}
+
 		// Handle object/list references
 		fieldTypeName := field.Type.Name()
-		if field.Type.Elem != nil {
+		propertyName := field.Name // field is shared AST, so renames stay local
+		if field.Type.Elem != nil && field.Type.Elem.Elem != nil {
+			// Nested list (e.g. [[Int!]!]) - keep it as a nested array property;
+			// only flat lists of objects become sub-resource endpoints
+		} else if field.Type.Elem != nil {
 			// This is a list
 			elemType := field.Type.Elem.NamedType
-			if !isScalarType(elemType) && !isBuiltInType(elemType) {
+			if !isScalarType(elemType) && !isBuiltInType(elemType) && c.mapScalarSchema(elemType) == nil {
 				// List of objects - don't embed it, it becomes a sub-resource endpoint
 				continue
 			}
 			// Scalar list - keep it as an array property (already converted by convertFieldType)
+		} else if c.isEnumType(fieldTypeName) {
+			// Enum values are embedded as a reference to the enum component
+		} else if c.mapScalarSchema(fieldTypeName) != nil || c.scalarSchema(fieldTypeName) != nil {
+			// Map and mapped scalars are values (already converted by convertFieldType)
+		} else if embedded := c.embeddedReference(fieldTypeName, depth); embedded != nil {
+			// Object reference embedded under Config.EmbedObjectReferences
+			if propSchema.Description != "" {
+				embedded.Description = propSchema.Description
+			}
+			propSchema = embedded
 		} else if !isScalarType(fieldTypeName) && !isBuiltInType(fieldTypeName) {
 			// This is an object reference - convert to ID
-			propSchema = &Schema{
-				Type:        "string",
-				Description: fmt.Sprintf("Reference to %s.id - use GET /%s/{%sId}", fieldTypeName, c.pluralize(strings.ToLower(fieldTypeName)), field.Name),
+			propSchema = c.idSchema()
+			propSchema.Description = fmt.Sprintf("Reference to %s.%s - use GET %s/{%sId}", fieldTypeName, c.idFieldName(fieldTypeName), c.typeCollectionPath(fieldTypeName), field.Name)
+			propertyName = field.Name + "Id"
+			if _, ok := c.typeDeprecation(fieldTypeName); ok {
+				propSchema.Description += fmt.Sprintf(" (%s is deprecated)", fieldTypeName)
 			}
-			field.Name = field.Name + "Id"
 		}
 
-		schema.Properties[field.Name] = propSchema
+		c.applyExample(propSchema, field.Directives)
+		c.applyDefault(propSchema, field.Directives)
+		propSchema.Extensions = c.directiveExtensions(propSchema.Extensions, field.Directives)
 
-		if field.Type.NonNull {
-			schema.Required = append(schema.Required, field.Name)
+		if c.config.AnnotateReadWriteOnly {
+			if typeDef.Kind == ast.Object {
+				propSchema.ReadOnly = true
+			} else {
+				propSchema.WriteOnly = true
+			}
 		}
-	}
 
-	c.doc.Components.Schemas[typeDef.Name] = schema
-}
+		required := c.isRequiredField(field)
+		if !required && c.config.NullableAsOneOf && c.isOpenAPI31() {
+			propSchema = nullableOneOf(propSchema)
+		} else if !required && !c.isOpenAPI31() && c.isEnumType(fieldTypeName) && propSchema.Ref != "" {
+			propSchema = nullableAllOf(propSchema)
+		} else if propSchema.Default != nil || propSchema.ReadOnly || propSchema.WriteOnly {
+			propSchema = c.allOfRef(propSchema)
+		}
 
-func (c *Converter) convertQueries(queryType *ast.Definition, restPatterns map[string]*RESTPattern) {
-	processedFields := make(map[string]bool)
+		schema.Properties[propertyName] = propSchema
+		propertyOrder = append(propertyOrder, propertyName)
 
-	// First, handle REST patterns
-	for resource, pattern := range restPatterns {
-		plural := pattern.Plural
-		path := c.addPrefix("/" + plural)
+		if required {
+			schema.Required = append(schema.Required, propertyName)
+		}
+	}
 
-		// List operation
-		if pattern.Operations["list"] {
-			if c.doc.Paths[path] == nil {
-				c.doc.Paths[path] = &PathItem{}
-			}
-			c.doc.Paths[path].Get = &Operation{
-				OperationID: "list" + c.capitalize(plural),
-				Summary:     "List " + plural,
-				Responses: map[string]*Response{
-					"200": {
-						Description: "Successful response",
-						Content: map[string]*MediaType{
-							"application/json": {
-								Schema: &Schema{
-									Type: "array",
-									Items: &Schema{
-										Ref: "#/components/schemas/" + pattern.Type.Name,
-									},
-								},
-							},
-						},
-					},
-				},
-			}
-			processedFields[plural] = true
+	// A oneOf input object accepts exactly one of its fields
+	if c.isOneOfInput(typeDef) {
+		for _, name := range propertyOrder {
+			schema.OneOf = append(schema.OneOf, c.strictObject(&Schema{
+				Type:       "object",
+				Properties: map[string]*Schema{name: schema.Properties[name]},
+				Required:   []string{name},
+			}))
 		}
+		schema.Properties = nil
+		schema.Required = nil
+	} else if len(inherited) == 0 {
+		// With allOf, the interface's properties would count as additional
+		c.strictObject(schema)
+	}
 
-		// Get by ID operation
-		if pattern.Operations["get"] {
-			idPath := c.addPrefix("/" + plural + "/{id}")
-			if c.doc.Paths[idPath] == nil {
-				c.doc.Paths[idPath] = &PathItem{}
-			}
-			c.doc.Paths[idPath].Get = &Operation{
-				OperationID: "get" + c.capitalize(resource),
-				Summary:     "Get " + resource + " by ID",
-				Parameters: []*Parameter{
-					{
-						Name:     "id",
-						In:       "path",
-						Required: true,
-						Schema:   &Schema{Type: "string"},
-					},
-				},
-				Responses: map[string]*Response{
-					"200": {
+	// Implementing types compose their interfaces with their own fields
+	if len(inherited) > 0 {
+		schema = c.composeInterfaces(typeDef, schema)
+	}
+	return schema
+}
+
+// embeddedReference returns the inline schema of the object type typeName for
+// a reference found depth levels deep, or nil when references are not embedded
+// or Config.MaxEmbedDepth (default 1) is reached; the reference then stays an ID
+func (c *Converter) embeddedReference(typeName string, depth int) *Schema {
+	if !c.config.EmbedObjectReferences {
+		return nil
+	}
+	maxDepth := c.config.MaxEmbedDepth
+	if maxDepth <= 0 {
+		maxDepth = 1
+	}
+	typeDef := c.schema.Types[typeName]
+	if depth >= maxDepth || typeDef == nil || typeDef.Kind != ast.Object || !c.typeIncluded(typeName) {
+		return nil
+	}
+	return c.objectSchema(typeDef, depth+1)
+}
+
+// isRequiredField reports whether field belongs in its schema's required list:
+// non-null fields normally, or every field not marked @optional under RequiredByDefault
+func (c *Converter) isRequiredField(field *ast.FieldDefinition) bool {
+	if c.config.RequiredByDefault {
+		return field.Directives.ForName("optional") == nil
+	}
+	return field.Type.NonNull
+}
+
+// typeIncluded reports whether a GraphQL type passes Config.IncludeTypes/ExcludeTypes
+func (c *Converter) typeIncluded(name string) bool {
+	if len(c.config.IncludeTypes) > 0 && !matchesAny(name, c.config.IncludeTypes) {
+		return false
+	}
+	return !matchesAny(name, c.config.ExcludeTypes)
+}
+
+// operationIncluded reports whether a query/mutation/subscription field passes
+// Config.IncludeOperations/ExcludeOperations
+func (c *Converter) operationIncluded(name string) bool {
+	if len(c.config.IncludeOperations) > 0 && !matchesAny(name, c.config.IncludeOperations) {
+		return false
+	}
+	return !matchesAny(name, c.config.ExcludeOperations)
+}
+
+// fieldIncluded reports whether a query/mutation/subscription field becomes an
+// endpoint: it passes the operation filters and lacks the internal directive
+func (c *Converter) fieldIncluded(field *ast.FieldDefinition) bool {
+	name := c.config.InternalDirective
+	if name == "" {
+		name = "internal"
+	}
+	return field.Directives.ForName(name) == nil && c.operationIncluded(field.Name)
+}
+
+// matchesAny reports whether name equals or glob-matches one of patterns
+func matchesAny(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isOneOfInput reports whether typeDef carries the configured oneOf directive,
+// which must be declared in the schema (or prelude) on INPUT_OBJECT
+func (c *Converter) isOneOfInput(typeDef *ast.Definition) bool {
+	if typeDef.Kind != ast.InputObject {
+		return false
+	}
+	name := c.config.OneOfInputDirective
+	if name == "" {
+		name = "oneOf"
+	}
+	if typeDef.Directives.ForName(name) == nil {
+		return false
+	}
+	definition := c.schema.Directives[name]
+	if definition == nil {
+		return false
+	}
+	for _, location := range definition.Locations {
+		if location == ast.LocationInputObject {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Converter) convertQueries(queryType *ast.Definition, restPatterns map[string]*RESTPattern) {
+	processedFields := make(map[string]bool)
+
+	// First, handle REST patterns, in resource order so that the same resource
+	// wins a colliding path every time
+	for _, resource := range sortedResources(restPatterns) {
+		pattern := restPatterns[resource]
+		plural := pattern.Plural
+		path := c.collectionPath(pattern)
+
+		// List operation
+		if pattern.Operations["list"] {
+			op := &Operation{
+				OperationID: "list" + c.capitalize(plural),
+				Tags:        []string{plural},
+				Summary:     "List " + plural,
+				Responses: map[string]*Response{
+					"200": {
+						Description: "Successful response",
+						Content: map[string]*MediaType{
+							"application/json": {
+								Schema: c.collectionSchema(pattern.Type.Name),
+							},
+						},
+					},
+				},
+			}
+			c.paginate(op, pattern.Type.Name)
+			listField := queryType.Fields.ForName(pattern.Fields["list"])
+			op.OperationID = c.operationID(op.OperationID, "get", plural, listField)
+			c.applySecurity(op, listField)
+			c.applyOperationDirectives(op, listField)
+			c.setOperation(path, "get", op)
+			processedFields[pattern.Fields["list"]] = true
+		}
+
+		// Get by ID operation
+		if pattern.Operations["get"] {
+			idPath := c.itemPath(pattern)
+			op := &Operation{
+				OperationID: "get" + c.capitalize(resource),
+				Tags:        []string{plural},
+				Summary:     "Get " + resource + " by ID",
+				Parameters:  []*Parameter{c.itemParameter(pattern)},
+				Responses: map[string]*Response{
+					"200": {
 						Description: "Successful response",
 						Content: map[string]*MediaType{
 							"application/json": {
 								Schema: &Schema{
-									Ref: "#/components/schemas/" + pattern.Type.Name,
+									Ref: c.schemaRef(pattern.Type.Name),
 								},
 							},
 						},
 					},
 				},
 			}
-			processedFields[resource] = true
+			getField := queryType.Fields.ForName(pattern.Fields["get"])
+			op.OperationID = c.operationID(op.OperationID, "get", resource, getField)
+			c.applySecurity(op, getField)
+			c.applyOperationDirectives(op, getField)
+			c.setOperation(idPath, "get", op)
+			processedFields[pattern.Fields["get"]] = true
+		}
+	}
+
+	// Query fields consolidated as create/update/delete or actions are converted with the mutations
+	for _, pattern := range restPatterns {
+		names := []string{pattern.Fields["create"], pattern.Fields["update"], pattern.Fields["delete"]}
+		for _, name := range pattern.Actions {
+			names = append(names, name)
+		}
+		for _, name := range names {
+			if field := c.mutationField(name); field != nil && queryType.Fields.ForName(field.Name) == field {
+				processedFields[field.Name] = true
+			}
 		}
 	}
 
@@ -454,216 +1365,902 @@ func (c *Converter) convertQueries(queryType *ast.Definition, restPatterns map[s
 		}
 
 		// Skip GraphQL introspection queries
-		if strings.HasPrefix(field.Name, "__") {
+		if strings.HasPrefix(field.Name, "__") || !c.fieldIncluded(field) {
 			continue
 		}
 
 		path := c.addPrefix("/" + field.Name)
-		operation := c.convertQueryField(field)
+		if singleton, ok := c.config.SingletonQueries[field.Name]; ok {
+			if len(field.Arguments) == 0 {
+				path = c.prefixPath(singleton)
+			} else {
+				c.warn("singleton query '%s' takes arguments, keeping path %s", field.Name, path)
+			}
+		}
+		// Write-like queries marked @httpMethod(method: "POST") take their arguments as a body
+		if c.queryMethod(field) == "post" {
+			operation := c.convertMutationField(field, "")
+			operation.OperationID = c.operationID(operation.OperationID, "post", field.Name, field)
+			c.applyOperationDirectives(operation, field)
+			c.setOperation(c.buildPath(PathContext{Kind: "query", Field: field.Name, Path: path}), "post", operation)
+			continue
+		}
 
-		if c.doc.Paths[path] == nil {
-			c.doc.Paths[path] = &PathItem{}
+		operation := c.convertQueryField(field)
+		if c.applyRESTDirective(field, operation, "get") {
+			continue
 		}
-		c.doc.Paths[path].Get = operation
+		if keyPath := c.compositeKeyPath(field, operation); keyPath != "" {
+			path = keyPath
+		}
+		operation.OperationID = c.operationID(operation.OperationID, "get", field.Name, field)
+		c.applyOperationDirectives(operation, field)
+
+		c.setOperation(c.buildPath(PathContext{Kind: "query", Field: field.Name, Path: path}), "get", operation)
 	}
 
 	// Add sub-resource endpoints for list fields on types
 	for _, typeDef := range c.schema.Types {
-		if isBuiltInType(typeDef.Name) || typeDef.Kind != ast.Object {
+		if isBuiltInType(typeDef.Name) || typeDef.Kind != ast.Object || !hasSubResources(typeDef) || !c.typeIncluded(typeDef.Name) {
 			continue
 		}
 
-		for _, field := range typeDef.Fields {
-			if field.Type.Elem != nil && field.Type.Elem.NamedType != "" {
-				// Skip scalar arrays (e.g., [String!]!) - they stay as fields, not sub-resources
-				if isScalarType(field.Type.Elem.NamedType) {
-					continue
-				}
+		visited := map[string]bool{typeDef.Name: true}
+		c.convertSubResources(typeDef, c.typeCollectionPath(typeDef.Name)+"/{id}", []*Parameter{c.idParameter()}, "get"+typeDef.Name, 1, visited)
+	}
+}
+
+// queryMethod returns the method of a query field: "get", or "post" when marked
+// @httpMethod(method: "POST")
+func (c *Converter) queryMethod(field *ast.FieldDefinition) string {
+	directive := field.Directives.ForName("httpMethod")
+	if directive == nil {
+		return "get"
+	}
+	arg := directive.Arguments.ForName("method")
+	if arg == nil || arg.Value.Kind != ast.StringValue {
+		return "get"
+	}
+	switch method := strings.ToLower(arg.Value.Raw); method {
+	case "get", "post":
+		return method
+	default:
+		c.warn("@httpMethod on %s: unsupported method %q, using GET", field.Name, arg.Value.Raw)
+		return "get"
+	}
+}
 
-				// This is a list field - create sub-resource endpoint
-				resourceName := strings.ToLower(typeDef.Name)
-				path := c.addPrefix("/" + c.pluralize(resourceName) + "/{id}/" + field.Name)
+// convertSubResources creates GET endpoints for the list fields of typeDef under
+// basePath, descending into the listed types until Config.MaxDepth is reached.
+// visited holds the types on the current path, so self-referential and mutually
+// recursive types (Category.children, A -> B -> A) are not expanded again
+func (c *Converter) convertSubResources(typeDef *ast.Definition, basePath string, params []*Parameter, opIDPrefix string, depth int, visited map[string]bool) {
+	resourceName := strings.ToLower(typeDef.Name)
 
-				if c.doc.Paths[path] == nil {
-					c.doc.Paths[path] = &PathItem{}
-				}
+	for _, field := range typeDef.Fields {
+		// Only flat lists become sub-resources; nested lists stay array properties
+		if field.Type.Elem == nil || field.Type.Elem.NamedType == "" {
+			continue
+		}
+		// Skip scalar arrays (e.g., [String!]!) - they stay as fields, not sub-resources
+		if isScalarType(field.Type.Elem.NamedType) || !c.typeIncluded(field.Type.Elem.NamedType) {
+			continue
+		}
 
-				c.doc.Paths[path].Get = &Operation{
-					OperationID: "get" + typeDef.Name + c.capitalize(field.Name),
-					Summary:     "Get " + field.Name + " by " + resourceName,
-					Parameters: []*Parameter{
-						{
-							Name:     "id",
-							In:       "path",
-							Required: true,
-							Schema:   &Schema{Type: "string"},
-						},
-					},
-					Responses: map[string]*Response{
-						"200": {
-							Description: "Successful response",
-							Content: map[string]*MediaType{
-								"application/json": {
-									Schema: &Schema{
-										Type: "array",
-										Items: &Schema{
-											Ref: "#/components/schemas/" + field.Type.Elem.NamedType,
-										},
-									},
-								},
-							},
+		// This is a list field - create sub-resource endpoint
+		subPath := basePath + "/" + field.Name
+		path := c.buildPath(PathContext{Kind: "subresource", Resource: c.uncapitalize(typeDef.Name), Field: field.Name, Path: c.addPrefix(subPath)})
+
+		op := &Operation{
+			OperationID: opIDPrefix + c.capitalize(field.Name),
+			Tags:        []string{typeDef.Name},
+			Summary:     "Get " + field.Name + " by " + resourceName,
+			Parameters:  append([]*Parameter{}, params...),
+			Responses: map[string]*Response{
+				"200": {
+					Description: "Successful response",
+					Content: map[string]*MediaType{
+						"application/json": {
+							Schema: c.collectionSchema(field.Type.Elem.NamedType),
 						},
 					},
-				}
+				},
+			},
+		}
+		op.OperationID = c.operationID(op.OperationID, "get", field.Name, field)
+		c.applySecurity(op, field)
+		c.setOperation(path, "get", op)
+
+		elemType := c.schema.Types[field.Type.Elem.NamedType]
+		if elemType == nil || elemType.Kind != ast.Object || !hasSubResources(elemType) || visited[elemType.Name] {
+			continue
+		}
+		if depth >= c.maxDepth() {
+			if c.config.MaxDepth > 0 {
+				c.warn("sub-resource nesting truncated at depth %d: %s", depth, path)
+			}
+			continue
+		}
+
+		// Nested item paths need a distinct parameter name per level
+		paramName := c.uncapitalize(elemType.Name) + "Id"
+		for _, p := range params {
+			if p.Name == paramName {
+				paramName = fmt.Sprintf("%s%d", paramName, depth+1)
+				break
+			}
+		}
+		nestedParams := append(append([]*Parameter{}, params...), &Parameter{
+			Name:     paramName,
+			In:       "path",
+			Required: true,
+			Schema:   c.idSchema(),
+			Example:  c.idExample(),
+		})
+		visited[elemType.Name] = true
+		c.convertSubResources(elemType, subPath+"/{"+paramName+"}", nestedParams, opIDPrefix+c.capitalize(field.Name), depth+1, visited)
+		delete(visited, elemType.Name)
+	}
+}
+
+// noContentResponse returns a 204 response, which has no body
+func (c *Converter) noContentResponse(description string) *Response {
+	response := &Response{Description: description}
+	if c.config.EmitContentTypeForEmptyResponses {
+		response.Content = Content{}
+	}
+	return response
+}
+
+// compositeKeyPath handles lookups by several required IDs, e.g.
+// membership(orgId: ID!, userId: ID!) -> GET /memberships/{orgId}/{userId}:
+// the IDs move from query to path parameters and the path is returned.
+// Queries with fewer than two required ID arguments return "".
+func (c *Converter) compositeKeyPath(field *ast.FieldDefinition, op *Operation) string {
+	keys := []string{}
+	for _, arg := range field.Arguments {
+		if arg.Type.NonNull && arg.Type.Elem == nil && arg.Type.NamedType == "ID" {
+			keys = append(keys, arg.Name)
+		}
+	}
+	if len(keys) < 2 {
+		return ""
+	}
+
+	resource := field.Name
+	if c.singularize(resource) == resource {
+		resource = c.pluralize(resource)
+	}
+	path := "/" + resource
+	for _, key := range keys {
+		path += "/{" + key + "}"
+		for _, param := range op.Parameters {
+			if param.Name == key {
+				param.In = "path"
+				param.Required = true
+				param.Style = ""
+				param.Explode = nil
+			}
+		}
+	}
+	return c.addPrefix(path)
+}
+
+// isItemIDArgument reports whether a get query's single argument identifies
+// an item: an ID, or an argument named after Config.ResourceIDFieldNames or
+// ending in Id (e.g. userId)
+func (c *Converter) isItemIDArgument(arg *ast.ArgumentDefinition) bool {
+	return c.isIDFieldName(arg.Name) || arg.Type.NamedType == "ID" || strings.HasSuffix(arg.Name, "Id")
+}
+
+// isIDFieldName reports whether name is one of Config.ResourceIDFieldNames
+func (c *Converter) isIDFieldName(name string) bool {
+	for _, idName := range c.config.ResourceIDFieldNames {
+		if name == idName {
+			return true
+		}
+	}
+	return false
+}
+
+// keyField returns the single field named by typeName's federation
+// @key(fields: "...") directive, or "" when there is none or the key is
+// compound (several fields, or a nested selection)
+func (c *Converter) keyField(typeName string) string {
+	typeDef := c.schema.Types[typeName]
+	if typeDef == nil {
+		return ""
+	}
+	directive := typeDef.Directives.ForName("key")
+	if directive == nil {
+		return ""
+	}
+	arg := directive.Arguments.ForName("fields")
+	if arg == nil || arg.Value == nil {
+		return ""
+	}
+	fields := strings.Fields(arg.Value.Raw)
+	if len(fields) != 1 || strings.ContainsAny(fields[0], "{}") || typeDef.Fields.ForName(fields[0]) == nil {
+		return ""
+	}
+	return fields[0]
+}
+
+// idFieldName returns the field identifying typeName: its federation @key
+// field, else the first of Config.ResourceIDFieldNames it declares, else the
+// first configured name
+func (c *Converter) idFieldName(typeName string) string {
+	if key := c.keyField(typeName); key != "" {
+		return key
+	}
+	if typeDef := c.schema.Types[typeName]; typeDef != nil {
+		for _, idName := range c.config.ResourceIDFieldNames {
+			if typeDef.Fields.ForName(idName) != nil {
+				return idName
 			}
 		}
 	}
+	if len(c.config.ResourceIDFieldNames) > 0 {
+		return c.config.ResourceIDFieldNames[0]
+	}
+	return "id"
+}
+
+// idParam returns the name of the pattern's item path parameter
+func (p *RESTPattern) idParam() string {
+	if p.IDParam == "" {
+		return "id"
+	}
+	return p.IDParam
+}
+
+// collectionPath returns the path of a REST resource's collection, e.g. /users
+func (c *Converter) collectionPath(pattern *RESTPattern) string {
+	path := c.addPrefix("/" + pattern.Plural)
+	if pattern.Path != "" {
+		path = c.prefixPath(pattern.Path)
+	}
+	return c.buildPath(PathContext{Kind: "collection", Resource: pattern.Resource, Field: pattern.Fields["list"], Path: path})
+}
+
+// itemPath returns the path of a single item of a REST resource, e.g. /users/{id}
+func (c *Converter) itemPath(pattern *RESTPattern) string {
+	path := c.collectionPath(pattern) + "/{" + pattern.idParam() + "}"
+	return c.buildPath(PathContext{Kind: "item", Resource: pattern.Resource, Field: pattern.Fields["get"], Path: path})
+}
+
+// buildPath returns the path Config.PathBuilder makes of ctx, or ctx.Path
+// when there is no builder
+func (c *Converter) buildPath(ctx PathContext) string {
+	if c.config.PathBuilder == nil {
+		return ctx.Path
+	}
+	return c.config.PathBuilder(ctx)
+}
+
+// resourceOverride returns the collection name and path set by a
+// @resource(name: "people", path: "/v1/people") directive on typeName
+func (c *Converter) resourceOverride(typeName string) (name, path string) {
+	typeDef := c.schema.Types[typeName]
+	if typeDef == nil {
+		return "", ""
+	}
+	directive := typeDef.Directives.ForName("resource")
+	if directive == nil {
+		return "", ""
+	}
+	if arg := directive.Arguments.ForName("name"); arg != nil && arg.Value.Kind == ast.StringValue {
+		name = arg.Value.Raw
+	}
+	if arg := directive.Arguments.ForName("path"); arg != nil && arg.Value.Kind == ast.StringValue {
+		path = arg.Value.Raw
+	}
+	return name, path
+}
+
+// resourcePlural pluralizes a resource name (user -> users) unless its type
+// names the collection with @resource
+func (c *Converter) resourcePlural(resource string) string {
+	if name, _ := c.resourceOverride(c.capitalize(resource)); name != "" {
+		return name
+	}
+	return c.pluralize(resource)
+}
+
+// typeCollectionPath returns the unprefixed collection path of a type, as used
+// by sub-resources and reference descriptions: its @resource path or name, or
+// else its pluralized lowercase name (/users)
+func (c *Converter) typeCollectionPath(typeName string) string {
+	name, path := c.resourceOverride(typeName)
+	if path != "" {
+		return path
+	}
+	if name != "" {
+		return "/" + name
+	}
+	return "/" + c.pluralize(strings.ToLower(typeName))
+}
+
+// itemParameter returns the item path parameter of a REST resource: the
+// shared IdParam for {id}, otherwise one typed after the get query's argument
+func (c *Converter) itemParameter(pattern *RESTPattern) *Parameter {
+	if pattern.idParam() == "id" {
+		return c.idParameter()
+	}
+	param := &Parameter{
+		Name:     pattern.idParam(),
+		In:       "path",
+		Required: true,
+		Schema:   c.idSchema(),
+		Example:  c.idExample(),
+	}
+	if getField := c.schema.Query.Fields.ForName(pattern.Fields["get"]); getField != nil && len(getField.Arguments) == 1 {
+		param.Schema = c.convertFieldType(getField.Arguments[0].Type)
+		if getField.Arguments[0].Type.Name() != "ID" {
+			param.Example = nil
+		}
+	}
+	return param
+}
+
+// idParameter returns a reference to the shared {id} path parameter,
+// defining it under components/parameters on first use
+func (c *Converter) idParameter() *Parameter {
+	return c.sharedParameter("IdParam", &Parameter{
+		Name:     "id",
+		In:       "path",
+		Required: true,
+		Schema:   c.idSchema(),
+		Example:  c.idExample(),
+	})
+}
+
+// sharedParameter returns a reference to components/parameters/name, defining
+// it as param on first use
+func (c *Converter) sharedParameter(name string, param *Parameter) *Parameter {
+	if c.doc.Components.Parameters == nil {
+		c.doc.Components.Parameters = make(map[string]*Parameter)
+	}
+	if c.doc.Components.Parameters[name] == nil {
+		c.doc.Components.Parameters[name] = param
+	}
+	return &Parameter{Ref: "#/components/parameters/" + name}
+}
+
+// collectionSchema returns the response schema for a list of typeName: an
+// inline array, or under Config.NamedCollectionSchemas a $ref to a {Type}List
+// component holding it in an items property
+func (c *Converter) collectionSchema(typeName string) *Schema {
+	array := &Schema{Type: "array", Items: &Schema{Ref: c.schemaRef(typeName)}}
+	if !c.config.NamedCollectionSchemas {
+		return array
+	}
+	return c.generatedComponent(typeName+"List", typeName+" collection", &Schema{
+		Type:       "object",
+		Properties: map[string]*Schema{"items": array},
+		Required:   []string{"items"},
+	}, array)
+}
+
+// generatedComponent registers schema as the component name, returning a $ref
+// to it. When a GraphQL type already has that name, fallback is inlined
+// instead, with a warning naming what, so the type's own schema is not shadowed
+func (c *Converter) generatedComponent(name, what string, schema, fallback *Schema) *Schema {
+	if c.schema.Types[name] != nil {
+		c.warn("%s is already a GraphQL type, inlining the %s", name, what)
+		return fallback
+	}
+	key := c.schemaName(name)
+	if c.doc.Components.Schemas[key] == nil {
+		c.doc.Components.Schemas[key] = schema
+	}
+	return &Schema{Ref: c.schemaRef(name)}
+}
+
+// paginate adds Config.PaginationStyle's query parameters to a REST list
+// operation and wraps its response, the array or its {Type}List component, in
+// a {data, ...} envelope component
+func (c *Converter) paginate(op *Operation, typeName string) {
+	var pageParam *Parameter
+	zero, one := 0.0, 1.0
+	content := op.Responses["200"].Content["application/json"]
+	envelope := &Schema{
+		Type:       "object",
+		Properties: map[string]*Schema{"data": content.Schema},
+		Required:   []string{"data"},
+	}
+	switch c.config.PaginationStyle {
+	case "offset":
+		pageParam = c.sharedParameter("OffsetParam", &Parameter{
+			Name:        "offset",
+			In:          "query",
+			Description: "Number of items to skip",
+			Schema:      &Schema{Type: "integer", Minimum: &zero, Default: 0},
+		})
+		envelope.Properties["total"] = &Schema{Type: "integer", Description: "Total number of items"}
+		envelope.Required = append(envelope.Required, "total")
+	case "cursor":
+		pageParam = c.sharedParameter("CursorParam", &Parameter{
+			Name:        "cursor",
+			In:          "query",
+			Description: "nextCursor from the previous page; omit for the first page",
+			Schema:      &Schema{Type: "string"},
+		})
+		envelope.Properties["nextCursor"] = &Schema{Type: "string", Description: "Cursor of the next page, absent on the last page"}
+	default:
+		return
+	}
+
+	limitParam := c.sharedParameter("LimitParam", &Parameter{
+		Name:        "limit",
+		In:          "query",
+		Description: "Maximum number of items to return",
+		Schema:      &Schema{Type: "integer", Minimum: &one},
+	})
+	op.Parameters = append(op.Parameters, limitParam, pageParam)
+
+	content.Schema = c.generatedComponent(typeName+"Page", typeName+" page envelope", envelope, envelope)
+	op.Responses["200"].Headers = map[string]*Header{
+		"X-Total-Count": {Description: "Total number of items across all pages", Schema: &Schema{Type: "integer"}},
+	}
+}
+
+// hasSubResources reports whether typeDef has list fields that become sub-resource endpoints
+func hasSubResources(typeDef *ast.Definition) bool {
+	for _, field := range typeDef.Fields {
+		if field.Type.Elem != nil && field.Type.Elem.NamedType != "" && !isScalarType(field.Type.Elem.NamedType) {
+			return true
+		}
+	}
+	return false
+}
+
+// maxDepth returns the sub-resource nesting limit (default 1: /{plural}/{id}/{field})
+func (c *Converter) maxDepth() int {
+	if c.config.MaxDepth > 0 {
+		return c.config.MaxDepth
+	}
+	return 1
 }
 
 func (c *Converter) convertMutations(mutationType *ast.Definition, restPatterns map[string]*RESTPattern) {
 	processedFields := make(map[string]bool)
 
-	// First, handle REST patterns
-	for resource, pattern := range restPatterns {
+	// First, handle REST patterns, in resource order as convertQueries does
+	for _, resource := range sortedResources(restPatterns) {
+		pattern := restPatterns[resource]
 		plural := pattern.Plural
+		createTakesPut := false
 
 		// Create operation
 		if pattern.Operations["create"] {
-			path := c.addPrefix("/" + plural)
-			if c.doc.Paths[path] == nil {
-				c.doc.Paths[path] = &PathItem{}
-			}
+			path := c.collectionPath(pattern)
 
 			// Find the create mutation field
-			var createField *ast.FieldDefinition
-			for _, field := range mutationType.Fields {
-				if field.Name == c.config.CRUDPrefixCreate+c.capitalize(resource) {
-					createField = field
-					break
-				}
-			}
+			createField := c.mutationField(pattern.Fields["create"])
 
 			if createField != nil {
-				c.doc.Paths[path].Post = c.convertMutationField(createField, "Create "+resource)
+				op := c.convertMutationField(createField, "Create "+resource)
+				op.Tags = []string{plural}
+				c.unwrapPayload(op, createField, pattern.Type)
+				if idArg := c.clientIDArgument(createField, pattern); idArg != nil {
+					// A client-supplied id makes the create an idempotent PUT to the item
+					removeBodyProperty(op, idArg.Name)
+					param := c.itemParameter(pattern)
+					if idArg.Type.NamedType != "ID" {
+						// Keep the format of a custom id scalar (e.g. UUID)
+						param = &Parameter{
+							Name:        pattern.idParam(),
+							In:          "path",
+							Required:    true,
+							Description: idArg.Description,
+							Schema:      c.convertArgumentType(idArg),
+						}
+					}
+					op.Parameters = append([]*Parameter{param}, op.Parameters...)
+					op.OperationID = c.operationID(op.OperationID, "put", resource, createField)
+					c.applyOperationDirectives(op, createField)
+					c.setOperation(c.itemPath(pattern), "put", op)
+					createTakesPut = true
+				} else {
+					op.OperationID = c.operationID(op.OperationID, "post", resource, createField)
+					c.applyOperationDirectives(op, createField)
+					c.setOperation(path, "post", op)
+				}
 				processedFields[createField.Name] = true
 			}
 		}
 
 		// Update operation
 		if pattern.Operations["update"] {
-			path := c.addPrefix("/" + plural + "/{id}")
-			if c.doc.Paths[path] == nil {
-				c.doc.Paths[path] = &PathItem{}
-			}
+			path := c.itemPath(pattern)
 
 			// Find the update mutation field
-			var updateField *ast.FieldDefinition
-			for _, field := range mutationType.Fields {
-				if field.Name == c.config.CRUDPrefixUpdate+c.capitalize(resource) {
-					updateField = field
-					break
-				}
-			}
+			updateField := c.mutationField(pattern.Fields["update"])
 
 			if updateField != nil {
 				op := c.convertMutationField(updateField, "Update "+resource)
+				op.Tags = []string{plural}
+				c.unwrapPayload(op, updateField, pattern.Type)
 				// Add id path parameter
-				op.Parameters = append([]*Parameter{
-					{
-						Name:     "id",
-						In:       "path",
-						Required: true,
-						Schema:   &Schema{Type: "string"},
-					},
-				}, op.Parameters...)
-				c.doc.Paths[path].Put = op
+				op.Parameters = append([]*Parameter{c.itemParameter(pattern)}, op.Parameters...)
+				c.applyOperationDirectives(op, updateField)
+				// A create that took PUT on the item leaves PATCH for the update
+				if c.config.UpdateUsesPatch || createTakesPut || c.isPartialUpdate(updateField) {
+					op.OperationID = c.operationID(op.OperationID, "patch", resource, updateField)
+					c.setOperation(path, "patch", op)
+				} else {
+					op.OperationID = c.operationID(op.OperationID, "put", resource, updateField)
+					c.setOperation(path, "put", op)
+				}
 				processedFields[updateField.Name] = true
 			}
 		}
 
 		// Delete operation
 		if pattern.Operations["delete"] {
-			path := c.addPrefix("/" + plural + "/{id}")
-			if c.doc.Paths[path] == nil {
-				c.doc.Paths[path] = &PathItem{}
-			}
+			path := c.itemPath(pattern)
 
 			// Find the delete mutation field
-			var deleteField *ast.FieldDefinition
-			for _, field := range mutationType.Fields {
-				if field.Name == c.config.CRUDPrefixDelete+c.capitalize(resource) {
-					deleteField = field
-					break
-				}
-			}
+			deleteField := c.mutationField(pattern.Fields["delete"])
 
 			if deleteField != nil {
 				op := c.convertMutationField(deleteField, "Delete "+resource)
-				// For delete, id is usually a parameter
-				if len(deleteField.Arguments) == 1 && deleteField.Arguments[0].Name == "id" {
-					op.Parameters = []*Parameter{
-						{
-							Name:     "id",
-							In:       "path",
-							Required: true,
-							Schema:   &Schema{Type: "string"},
-						},
-					}
+				op.Tags = []string{plural}
+				// For delete, id is usually a parameter, possibly wrapped in an input (deleteUser(input: {id}))
+				if len(deleteField.Arguments) == 1 && (c.isIDFieldName(deleteField.Arguments[0].Name) || deleteField.Arguments[0].Name == pattern.idParam() ||
+					c.isIDWrapperInput(deleteField.Arguments[0], pattern)) {
+					op.Parameters = []*Parameter{c.itemParameter(pattern)}
 					op.RequestBody = nil
 				}
-				c.doc.Paths[path].Delete = op
+				// A Boolean result only signals success, which the status code already does
+				if deleteField.Type.NamedType == "Boolean" {
+					delete(op.Responses, "200")
+					op.Responses["204"] = c.noContentResponse("Deleted")
+				}
+				op.OperationID = c.operationID(op.OperationID, "delete", resource, deleteField)
+				c.applyOperationDirectives(op, deleteField)
+				c.setOperation(path, "delete", op)
 				processedFields[deleteField.Name] = true
 			}
 		}
+
+		// Action operations (e.g. POST /users/{id}/archive)
+		for subPath, fieldName := range pattern.Actions {
+			actionField := c.mutationField(fieldName)
+			if actionField == nil {
+				continue
+			}
+			path := c.itemPath(pattern) + "/" + c.casePath(subPath)
+			op := c.convertMutationField(actionField, "")
+			op.Tags = []string{plural}
+			if idArg := c.itemIDArgument(actionField); idArg != nil {
+				removeBodyProperty(op, idArg.Name)
+			}
+			op.Parameters = append([]*Parameter{c.itemParameter(pattern)}, op.Parameters...)
+			if response := op.Responses["200"]; response != nil && response.Content["application/json"] != nil {
+				response.Content["application/json"].Schema = &Schema{Ref: c.schemaRef(pattern.Type.Name)}
+			}
+			op.OperationID = c.operationID(op.OperationID, "post", resource, actionField)
+			c.applyOperationDirectives(op, actionField)
+			c.setOperation(path, "post", op)
+			processedFields[fieldName] = true
+		}
 	}
 
 	// Then handle remaining mutations
+	if mutationType == nil {
+		return
+	}
 	for _, field := range mutationType.Fields {
-		if processedFields[field.Name] {
+		if processedFields[field.Name] || !c.fieldIncluded(field) {
 			continue
 		}
 
-		path := c.addPrefix("/" + field.Name)
 		operation := c.convertMutationField(field, "")
-
-		if c.doc.Paths[path] == nil {
-			c.doc.Paths[path] = &PathItem{}
+		if c.applyRESTDirective(field, operation, "post") {
+			continue
+		}
+		path := "/" + field.Name
+		if c.config.SplitHybridMutations {
+			path = c.splitHybridArguments(field, operation)
 		}
-		c.doc.Paths[path].Post = operation
+		path = c.buildPath(PathContext{Kind: "mutation", Field: field.Name, Path: c.addPrefix(path)})
+		operation.OperationID = c.operationID(operation.OperationID, "post", field.Name, field)
+		c.applyOperationDirectives(operation, field)
+
+		c.setOperation(path, "post", operation)
 	}
 }
 
-func (c *Converter) convertSubscriptions(subscriptionType *ast.Definition) {
-	for _, field := range subscriptionType.Fields {
-		// Skip GraphQL introspection fields
-		if strings.HasPrefix(field.Name, "__") {
-			continue
-		}
+// pathTemplateParam matches {argName} placeholders in a @rest path
+var pathTemplateParam = regexp.MustCompile(`\{(\w+)\}`)
 
-		operation := c.convertSubscriptionField(field)
-		path := c.buildSubscriptionPath(field)
+// applyRESTDirective places op at the method and path given by the field's
+// @rest(method: "POST", path: "/search/{term}") directive, reporting whether it
+// was present. Arguments named in the path template become path parameters.
+func (c *Converter) applyRESTDirective(field *ast.FieldDefinition, op *Operation, defaultMethod string) bool {
+	directive := field.Directives.ForName("rest")
+	if directive == nil {
+		return false
+	}
 
-		if c.doc.Paths[path] == nil {
-			c.doc.Paths[path] = &PathItem{}
+	method := defaultMethod
+	if arg := directive.Arguments.ForName("method"); arg != nil && arg.Value.Kind == ast.StringValue {
+		switch m := strings.ToLower(arg.Value.Raw); m {
+		case "get", "post", "put", "patch", "delete", "options":
+			method = m
+		default:
+			c.warn("@rest on %s: unsupported method %q, using %s", field.Name, arg.Value.Raw, strings.ToUpper(defaultMethod))
 		}
-		c.doc.Paths[path].Get = operation
 	}
-}
+	path := "/" + field.Name
+	if arg := directive.Arguments.ForName("path"); arg != nil && arg.Value.Kind == ast.StringValue {
+		path = arg.Value.Raw
+	}
 
-func (c *Converter) buildSubscriptionPath(field *ast.FieldDefinition) string {
-	basePath := "/" + field.Name
+	// Move templated arguments out of the query string / request body
+	var pathParams []*Parameter
+	for _, match := range pathTemplateParam.FindAllStringSubmatch(path, -1) {
+		name := match[1]
+		arg := field.Arguments.ForName(name)
+		if arg == nil {
+			c.warn("@rest path %s on %s: no argument named %s", path, field.Name, name)
+			continue
+		}
+		params := op.Parameters[:0]
+		for _, param := range op.Parameters {
+			if param.Name != name {
+				params = append(params, param)
+			}
+		}
+		op.Parameters = params
+		removeBodyProperty(op, name)
+		param := &Parameter{
+			Name:        name,
+			In:          "path",
+			Required:    true,
+			Description: arg.Description,
+			Schema:      c.convertArgumentType(arg),
+		}
+		c.applyParameterExample(param, arg)
+		pathParams = append(pathParams, param)
+	}
+	op.Parameters = append(pathParams, op.Parameters...)
+	op.OperationID = c.operationID(op.OperationID, method, field.Name, field)
+	c.applyOperationDirectives(op, field)
+
+	c.setOperation(c.buildPath(PathContext{Kind: "rest", Field: field.Name, Path: c.prefixPath(path)}), method, op)
+	return true
+}
+
+// setOperation stores op as the method operation of path. An operation already
+// there is never replaced: op moves to path/{operationId} (numbered if that is
+// taken too) and a warning names both. Returns the path op ended up at.
+func (c *Converter) setOperation(path, method string, op *Operation) string {
+	candidate := path
+	for n := 1; ; n++ {
+		if c.doc.Paths[candidate] == nil {
+			c.doc.Paths[candidate] = &PathItem{}
+		}
+		slot := operationSlot(c.doc.Paths[candidate], method)
+		if *slot == nil {
+			*slot = op
+			if candidate != path {
+				c.warn("%s %s is already taken by %s, moving %s to %s", strings.ToUpper(method), path, (*operationSlot(c.doc.Paths[path], method)).OperationID, op.OperationID, candidate)
+			}
+			return candidate
+		}
+		candidate = path + "/" + op.OperationID
+		if n > 1 {
+			candidate = fmt.Sprintf("%s/%s%d", path, op.OperationID, n)
+		}
+	}
+}
+
+// operationSlot returns the field of item that holds the method's operation
+func operationSlot(item *PathItem, method string) **Operation {
+	switch method {
+	case "put":
+		return &item.Put
+	case "post":
+		return &item.Post
+	case "delete":
+		return &item.Delete
+	case "options":
+		return &item.Options
+	case "patch":
+		return &item.Patch
+	case "head":
+		return &item.Head
+	default:
+		return &item.Get
+	}
+}
+
+// splitHybridArguments moves the ID arguments of a mutation that also takes
+// other input out of the request body under Config.SplitHybridMutations,
+// returning the operation's path. As with
+// subscriptions, the first required one becomes a path parameter
+// (approveOrder(id: ID!, input: ...) -> POST /approveOrder/{id}) and the rest
+// query parameters; mutations taking only IDs keep them in the body.
+func (c *Converter) splitHybridArguments(field *ast.FieldDefinition, op *Operation) string {
+	path := "/" + field.Name
+
+	var ids []*ast.ArgumentDefinition
+	for _, arg := range field.Arguments {
+		if arg.Type.Elem == nil && arg.Type.NamedType == "ID" {
+			ids = append(ids, arg)
+		}
+	}
+	if len(ids) == 0 || len(ids) == len(field.Arguments) {
+		return path
+	}
+
+	pathParamUsed := false
+	for _, arg := range ids {
+		removeBodyProperty(op, arg.Name)
+		param := &Parameter{
+			Name:        arg.Name,
+			In:          "query",
+			Required:    arg.Type.NonNull,
+			Schema:      c.convertArgumentType(arg),
+			Description: arg.Description,
+		}
+		if arg.Type.NonNull && !pathParamUsed {
+			param.In = "path"
+			path += "/{" + arg.Name + "}"
+			pathParamUsed = true
+		}
+		c.applyParameterExample(param, arg)
+		op.Parameters = append(op.Parameters, param)
+	}
+	return path
+}
+
+// clientIDArgument returns the required id argument of a create mutation that
+// lets the client choose the new item's id (createUser(id: ID!, ...)), or nil
+func (c *Converter) clientIDArgument(field *ast.FieldDefinition, pattern *RESTPattern) *ast.ArgumentDefinition {
+	for _, arg := range field.Arguments {
+		if arg.Type.NonNull && arg.Type.Elem == nil && (c.isIDFieldName(arg.Name) || arg.Name == pattern.idParam()) {
+			return arg
+		}
+	}
+	return nil
+}
+
+// isIDWrapperInput reports whether arg is an input object whose only field is
+// the item id, e.g. DeleteUserInput { id: ID! }
+func (c *Converter) isIDWrapperInput(arg *ast.ArgumentDefinition, pattern *RESTPattern) bool {
+	input := c.schema.Types[arg.Type.Name()]
+	if arg.Type.Elem != nil || input == nil || input.Kind != ast.InputObject || len(input.Fields) != 1 {
+		return false
+	}
+	field := input.Fields[0]
+	return field.Type.Elem == nil && (c.isIDFieldName(field.Name) || field.Name == pattern.idParam())
+}
+
+// itemIDArgument returns the argument identifying the item an action mutation
+// applies to: one named after Config.ResourceIDFieldNames, else the first
+// required ID argument
+func (c *Converter) itemIDArgument(field *ast.FieldDefinition) *ast.ArgumentDefinition {
+	for _, idName := range c.config.ResourceIDFieldNames {
+		if arg := field.Arguments.ForName(idName); arg != nil {
+			return arg
+		}
+	}
+	for _, arg := range field.Arguments {
+		if arg.Type.NonNull && arg.Type.NamedType == "ID" {
+			return arg
+		}
+	}
+	return nil
+}
+
+// removeBodyProperty drops an argument from op's JSON request body, removing
+// the body altogether once it has no properties left
+func removeBodyProperty(op *Operation, name string) {
+	if op.RequestBody == nil {
+		return
+	}
+	body := op.RequestBody.Content["application/json"].Schema
+	if body.Type != "object" {
+		// A bulk mutation's array body has no properties to move
+		return
+	}
+	delete(body.Properties, name)
+	required := []string{}
+	for _, r := range body.Required {
+		if r != name {
+			required = append(required, r)
+		}
+	}
+	body.Required = required
+	if len(body.Properties) == 0 {
+		op.RequestBody = nil
+	}
+}
+
+func (c *Converter) convertSubscriptions(subscriptionType *ast.Definition) {
+	for _, field := range subscriptionType.Fields {
+		// Skip GraphQL introspection fields
+		if strings.HasPrefix(field.Name, "__") || !c.fieldIncluded(field) {
+			continue
+		}
+
+		operation := c.convertSubscriptionField(field)
+		operation.OperationID = c.operationID(operation.OperationID, "get", field.Name, field)
+		path := c.buildSubscriptionPath(field)
+
+		c.setOperation(path, "get", operation)
+
+		// 3.1 can describe the push itself: the server POSTs each event to a subscriber URL
+		if c.isOpenAPI31() {
+			if c.doc.Webhooks == nil {
+				c.doc.Webhooks = make(map[string]*PathItem)
+			}
+			c.doc.Webhooks[field.Name] = &PathItem{Post: c.subscriptionWebhook(field)}
+		}
+	}
+}
+
+// subscriptionWebhook describes a subscription as a webhook delivering each
+// event as the request body
+func (c *Converter) subscriptionWebhook(field *ast.FieldDefinition) *Operation {
+	summary, description := c.splitDescription(c.addFieldNamePrefix(field.Name, field.Description))
+	op := &Operation{
+		OperationID: c.operationID("on"+c.capitalize(field.Name), "post", field.Name, field),
+		Tags:        []string{c.returnTypeTag(field, "Subscription")},
+		Summary:     summary,
+		Description: description,
+		RequestBody: &RequestBody{
+			Description: "The " + field.Type.Name() + " event",
+			Required:    true,
+			Content: map[string]*MediaType{
+				"application/json": {Schema: c.convertFieldType(field.Type)},
+			},
+		},
+		Responses: map[string]*Response{
+			"200": {Description: "Event received"},
+		},
+	}
+	c.applySecurity(op, field)
+	return op
+}
+
+func (c *Converter) buildSubscriptionPath(field *ast.FieldDefinition) string {
+	basePath := "/" + field.Name
 
 	// Find the first required parameter to use in the path
 	// This follows REST conventions where required params are in the path
 	for _, arg := range field.Arguments {
 		if arg.Type.NonNull {
 			// First required parameter goes in the path
-			return c.addPrefix(basePath + "/{" + arg.Name + "}")
+			basePath += "/{" + arg.Name + "}"
+			break
 		}
 	}
+	return c.buildPath(PathContext{Kind: "subscription", Field: field.Name, Path: c.addPrefix(basePath)})
+}
 
-	// No required parameters, just use the base path
-	return c.addPrefix(basePath)
+// sseEventSchema describes one Server-Sent Event of a subscription: the event
+// name and a data line holding the JSON-encoded return value
+func (c *Converter) sseEventSchema(field *ast.FieldDefinition, returnTypeName string) *Schema {
+	data := c.convertFieldType(field.Type)
+	data.Description = fmt.Sprintf("JSON-encoded %s", returnTypeName)
+	if data.Ref != "" {
+		// Keep the description beside the $ref valid in OpenAPI 3.0
+		data = &Schema{Description: data.Description, AllOf: []*Schema{{Ref: data.Ref}}}
+	}
+	return &Schema{
+		Type:        "object",
+		Description: fmt.Sprintf("Server-Sent Events stream. Each event contains a %s object in JSON format.", returnTypeName),
+		Properties: map[string]*Schema{
+			"event": {Type: "string", Enum: []string{field.Name}},
+			"data":  data,
+		},
+		Required: []string{"data"},
+	}
 }
 
 func (c *Converter) convertSubscriptionField(field *ast.FieldDefinition) *Operation {
@@ -673,12 +2270,9 @@ func (c *Converter) convertSubscriptionField(field *ast.FieldDefinition) *Operat
 
 	// Get the return type name for the event type
 	returnTypeName := field.Type.Name()
-	if field.Type.Elem != nil {
-		returnTypeName = field.Type.Elem.NamedType
-	}
 
 	// Build SSE format description
-	sseDescription := fmt.Sprintf(`Server-Sent Events (SSE) stream.
+	transportDescription := fmt.Sprintf(`Server-Sent Events (SSE) stream.
 
 Each event is formatted as:
   event: %s
@@ -690,29 +2284,45 @@ Example:
 
 The connection remains open and events are pushed as they occur.
 Use the EventSource API in browsers or any SSE client library.`, field.Name, returnTypeName, field.Name)
+	responses := map[string]*Response{
+		"200": {
+			Description: "SSE stream of " + returnTypeName + " events",
+			Content: map[string]*MediaType{
+				"text/event-stream": {
+					Schema: c.sseEventSchema(field, returnTypeName),
+				},
+			},
+		},
+	}
+
+	if c.config.SubscriptionTransport == "websocket" {
+		transportDescription = fmt.Sprintf(`WebSocket stream.
+
+Connect a WebSocket client to this path on the server's ws:// (or wss://) URL.
+The server upgrades the connection and sends each event as a text message
+holding a JSON-encoded %s object, until either side closes it.`, returnTypeName)
+		responses = map[string]*Response{
+			"101": {
+				Description: "Switching Protocols: the connection is upgraded to a WebSocket carrying " + returnTypeName + " events",
+				Headers: map[string]*Header{
+					"Upgrade":    {Description: "websocket", Schema: &Schema{Type: "string", Enum: []string{"websocket"}}},
+					"Connection": {Description: "Upgrade", Schema: &Schema{Type: "string", Enum: []string{"Upgrade"}}},
+				},
+			},
+		}
+	}
 
 	if description != "" {
-		sseDescription = description + "\n\n" + sseDescription
+		transportDescription = description + "\n\n" + transportDescription
 	}
 
 	op := &Operation{
 		OperationID: "subscribe" + c.capitalize(field.Name),
+		Tags:        []string{c.returnTypeTag(field, "Subscription")},
 		Summary:     "Subscribe: " + summary,
-		Description: sseDescription,
+		Description: transportDescription,
 		Parameters:  []*Parameter{},
-		Responses: map[string]*Response{
-			"200": {
-				Description: "SSE stream of " + returnTypeName + " events",
-				Content: map[string]*MediaType{
-					"text/event-stream": {
-						Schema: &Schema{
-							Type:        "string",
-							Description: fmt.Sprintf("Server-Sent Events stream. Each event contains a %s object in JSON format.", returnTypeName),
-						},
-					},
-				},
-			},
-		},
+		Responses:   responses,
 	}
 
 	if op.Summary == "" || op.Summary == "Subscribe: " {
@@ -745,9 +2355,11 @@ Use the EventSource API in browsers or any SSE client library.`, field.Name, ret
 				Name:        arg.Name,
 				In:          "path",
 				Required:    true,
-				Schema:      c.convertFieldType(arg.Type),
+				Schema:      c.convertArgumentType(arg),
 				Description: arg.Description,
 			}
+			c.applyArgumentDeprecation(param, arg)
+			c.applyParameterExample(param, arg)
 			op.Parameters = append(op.Parameters, param)
 			pathParamUsed = true
 		} else {
@@ -756,20 +2368,19 @@ Use the EventSource API in browsers or any SSE client library.`, field.Name, ret
 				Name:        arg.Name,
 				In:          "query",
 				Required:    arg.Type.NonNull,
-				Schema:      c.convertFieldType(arg.Type),
+				Schema:      c.convertArgumentType(arg),
 				Description: arg.Description,
 			}
 
-			// Handle array parameters with explode
-			if arg.Type.Elem != nil {
-				param.Style = "form"
-				param.Explode = true
-			}
+			c.applyArgumentDeprecation(param, arg)
+			c.applyParameterExample(param, arg)
+			c.setQueryStyle(param, arg)
 
 			op.Parameters = append(op.Parameters, param)
 		}
 	}
 
+	c.applySecurity(op, field)
 	return op
 }
 
@@ -780,6 +2391,7 @@ func (c *Converter) convertQueryField(field *ast.FieldDefinition) *Operation {
 
 	op := &Operation{
 		OperationID: field.Name,
+		Tags:        []string{c.returnTypeTag(field, "Query")},
 		Summary:     summary,
 		Description: description,
 		Parameters:  []*Parameter{},
@@ -788,7 +2400,7 @@ func (c *Converter) convertQueryField(field *ast.FieldDefinition) *Operation {
 				Description: "Successful response",
 				Content: map[string]*MediaType{
 					"application/json": {
-						Schema: c.convertFieldType(field.Type),
+						Schema: c.responseSchema(field.Type),
 					},
 				},
 			},
@@ -821,25 +2433,64 @@ func (c *Converter) convertQueryField(field *ast.FieldDefinition) *Operation {
 			Name:     arg.Name,
 			In:       "query",
 			Required: arg.Type.NonNull,
-			Schema:   c.convertFieldType(arg.Type),
+			Schema:   c.convertArgumentType(arg),
 		}
 
 		if arg.Description != "" {
 			param.Description = arg.Description
 		}
 
-		// Handle array parameters with explode
-		if arg.Type.Elem != nil {
-			param.Style = "form"
-			param.Explode = true
-		}
+		c.applyArgumentDeprecation(param, arg)
+		c.applyParameterExample(param, arg)
+		c.setQueryStyle(param, arg)
 
 		op.Parameters = append(op.Parameters, param)
 	}
 
+	c.applySecurity(op, field)
 	return op
 }
 
+// applyArgumentDeprecation marks a parameter deprecated when its argument is
+// @deprecated, appending the reason to the parameter description
+func (c *Converter) applyArgumentDeprecation(param *Parameter, arg *ast.ArgumentDefinition) {
+	deprecated := arg.Directives.ForName("deprecated")
+	if deprecated == nil {
+		return
+	}
+	param.Deprecated = true
+	if reason := deprecated.Arguments.ForName("reason"); reason != nil {
+		depReason := "DEPRECATED: " + strings.Trim(reason.Value.String(), "\"")
+		if param.Description != "" {
+			param.Description += "\n\n" + depReason
+		} else {
+			param.Description = depReason
+		}
+	}
+}
+
+// setQueryStyle sets how a query parameter is serialized: lists per
+// Config.ArrayParamStyle, input objects as deepObject (filter[name]=...)
+func (c *Converter) setQueryStyle(param *Parameter, arg *ast.ArgumentDefinition) {
+	explode := true
+	if arg.Type.Elem != nil {
+		switch c.config.ArrayParamStyle {
+		case "form-csv":
+			param.Style = "form"
+			explode = false
+		case "spaceDelimited", "pipeDelimited":
+			param.Style = c.config.ArrayParamStyle
+			explode = false
+		default:
+			param.Style = "form"
+		}
+		param.Explode = &explode
+	} else if typeDef := c.schema.Types[arg.Type.NamedType]; typeDef != nil && typeDef.Kind == ast.InputObject {
+		param.Style = "deepObject"
+		param.Explode = &explode
+	}
+}
+
 func (c *Converter) convertMutationField(field *ast.FieldDefinition, fallbackSummary string) *Operation {
 	var opSummary, opDescription string
 
@@ -856,164 +2507,1527 @@ func (c *Converter) convertMutationField(field *ast.FieldDefinition, fallbackSum
 		opDescription = ""
 	}
 
-	op := &Operation{
-		OperationID: field.Name,
-		Summary:     opSummary,
-		Description: opDescription,
-		Parameters:  []*Parameter{},
+	op := &Operation{
+		OperationID: field.Name,
+		Tags:        []string{c.returnTypeTag(field, "Mutation")},
+		Summary:     opSummary,
+		Description: opDescription,
+		Parameters:  []*Parameter{},
+		Responses: map[string]*Response{
+			"200": {
+				Description: "Successful response",
+				Content: map[string]*MediaType{
+					"application/json": {
+						Schema: c.responseSchema(field.Type),
+					},
+				},
+			},
+		},
+	}
+
+	// Handle deprecated directive
+	deprecated := field.Directives.ForName("deprecated")
+	if deprecated != nil {
+		op.Deprecated = true
+		if reason := deprecated.Arguments.ForName("reason"); reason != nil {
+			depReason := strings.Trim(reason.Value.String(), "\"")
+			op.Summary = "DEPRECATED: " + depReason
+			if op.Description != "" {
+				op.Description = "DEPRECATED: " + depReason + "\n\n" + op.Description
+			} else {
+				op.Description = "DEPRECATED: " + depReason
+			}
+		}
+	}
+
+	// Convert arguments to request body; a bulk mutation's single list of inputs
+	// (createUsers(inputs: [CreateUserInput!]!)) is the body itself
+	if len(field.Arguments) == 1 && c.isInputList(field.Arguments[0]) {
+		arg := field.Arguments[0]
+		bodySchema := c.convertArgumentType(arg)
+		if arg.Description != "" {
+			bodySchema.Description = arg.Description
+		}
+		op.RequestBody = &RequestBody{
+			Required: arg.Type.NonNull,
+			Content: map[string]*MediaType{
+				"application/json": {
+					Schema: bodySchema,
+				},
+			},
+		}
+	} else if len(field.Arguments) > 0 {
+		bodySchema := &Schema{
+			Type:       "object",
+			Properties: make(map[string]*Schema),
+			Required:   []string{},
+		}
+
+		for _, arg := range field.Arguments {
+			propSchema := c.convertArgumentType(arg)
+			if arg.Description != "" {
+				propSchema.Description = arg.Description
+			}
+			bodySchema.Properties[arg.Name] = propSchema
+			if arg.Type.NonNull {
+				bodySchema.Required = append(bodySchema.Required, arg.Name)
+			}
+		}
+
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]*MediaType{
+				"application/json": {
+					Schema: c.strictObject(bodySchema),
+				},
+			},
+		}
+	}
+
+	c.applySecurity(op, field)
+	return op
+}
+
+// isInputList reports whether arg is a flat list of input objects
+func (c *Converter) isInputList(arg *ast.ArgumentDefinition) bool {
+	if arg.Type.Elem == nil || arg.Type.Elem.Elem != nil {
+		return false
+	}
+	typeDef := c.schema.Types[arg.Type.Elem.NamedType]
+	return typeDef != nil && typeDef.Kind == ast.InputObject
+}
+
+// isPartialUpdate reports whether an update mutation only takes optional input
+// besides its id (e.g. updateUser(id: ID!, input: UpdateUserInput) where every
+// UpdateUserInput field is nullable), making it a PATCH rather than a PUT
+func (c *Converter) isPartialUpdate(field *ast.FieldDefinition) bool {
+	inputs := 0
+	for _, arg := range field.Arguments {
+		if c.isIDFieldName(arg.Name) {
+			continue
+		}
+		inputs++
+		argType := c.schema.Types[arg.Type.Name()]
+		if arg.Type.Elem == nil && argType != nil && argType.Kind == ast.InputObject {
+			for _, inputField := range argType.Fields {
+				if c.isRequiredField(inputField) {
+					return false
+				}
+			}
+		} else if arg.Type.NonNull {
+			return false
+		}
+	}
+	return inputs > 0
+}
+
+// applyOperationDirectives applies the field directives that describe the
+// operation as a whole: @returns, @response, @header and @externalDocs
+func (c *Converter) applyOperationDirectives(op *Operation, field *ast.FieldDefinition) {
+	if field == nil {
+		return
+	}
+	c.applyReturnsDirective(op, field)
+	c.applyResponseDirectives(op, field)
+	c.applyHeaderDirectives(op, field)
+	op.Extensions = c.directiveExtensions(op.Extensions, field.Directives)
+	if docs := field.Directives.ForName("externalDocs"); docs != nil {
+		if url := docs.Arguments.ForName("url"); url != nil && url.Value.Raw != "" {
+			op.ExternalDocs = &ExternalDocumentation{URL: url.Value.Raw}
+			if description := docs.Arguments.ForName("description"); description != nil {
+				op.ExternalDocs.Description = description.Value.Raw
+			}
+		}
+	}
+}
+
+// applyHeaderDirectives adds a header parameter to op for each of the field's
+// Config.HeaderDirectiveName directives, @header(name: "X-Tenant-ID", required: true)
+func (c *Converter) applyHeaderDirectives(op *Operation, field *ast.FieldDefinition) {
+	name := c.config.HeaderDirectiveName
+	if name == "" {
+		name = "header"
+	}
+	for _, directive := range field.Directives.ForNames(name) {
+		nameArg := directive.Arguments.ForName("name")
+		if nameArg == nil || nameArg.Value.Raw == "" {
+			c.warn("@%s on %s has no name", name, field.Name)
+			continue
+		}
+		param := &Parameter{
+			Name:   nameArg.Value.Raw,
+			In:     "header",
+			Schema: &Schema{Type: "string"},
+		}
+		if required := directive.Arguments.ForName("required"); required != nil {
+			param.Required = required.Value.Raw == "true"
+		}
+		if description := directive.Arguments.ForName("description"); description != nil {
+			param.Description = description.Value.Raw
+		}
+		op.Parameters = append(op.Parameters, param)
+	}
+}
+
+// applyReturnsDirective documents the success response of op as the type named
+// by the field's @returns(type: "User"), for fields whose GraphQL return type
+// (e.g. Boolean) is not what the endpoint is meant to return. A 204 response
+// becomes a 200 with that body. checkReturnsDirectives has vetted the type.
+func (c *Converter) applyReturnsDirective(op *Operation, field *ast.FieldDefinition) {
+	directive := field.Directives.ForName("returns")
+	if directive == nil {
+		return
+	}
+	typeArg := directive.Arguments.ForName("type")
+	if typeArg == nil || typeArg.Value.Raw == "" {
+		return
+	}
+	schema := &Schema{Ref: c.schemaRef(typeArg.Value.Raw)}
+	if field.Type.Elem != nil {
+		schema = &Schema{Type: "array", Items: schema}
+	}
+
+	replaced := false
+	for code, response := range op.Responses {
+		if strings.HasPrefix(code, "2") && code != "204" {
+			response.Content = Content{"application/json": {Schema: schema}}
+			replaced = true
+		}
+	}
+	if !replaced {
+		delete(op.Responses, "204")
+		op.Responses["200"] = &Response{
+			Description: "Successful response",
+			Content:     Content{"application/json": {Schema: schema}},
+		}
+	}
+}
+
+// checkReturnsDirectives reports a @returns directive on a root field that
+// names a type without a component schema (unknown, a scalar or filtered out)
+func (c *Converter) checkReturnsDirectives() error {
+	for _, root := range []*ast.Definition{c.schema.Query, c.schema.Mutation, c.schema.Subscription} {
+		if root == nil {
+			continue
+		}
+		for _, field := range root.Fields {
+			directive := field.Directives.ForName("returns")
+			if directive == nil {
+				continue
+			}
+			typeArg := directive.Arguments.ForName("type")
+			if typeArg == nil || typeArg.Value.Raw == "" {
+				return fmt.Errorf("@returns on %s.%s: missing type argument", root.Name, field.Name)
+			}
+			name := typeArg.Value.Raw
+			typeDef := c.schema.Types[name]
+			if typeDef == nil || typeDef.Kind == ast.Scalar || isBuiltInType(name) || !c.typeIncluded(name) {
+				return fmt.Errorf("@returns on %s.%s: %q is not a type with a schema in components", root.Name, field.Name, name)
+			}
+		}
+	}
+	return nil
+}
+
+// applyResponseDirectives replaces the default success response of op with
+// the field's @response(status: 201, description: "Created") directives, one
+// response per directive. Success statuses keep the default response body.
+func (c *Converter) applyResponseDirectives(op *Operation, field *ast.FieldDefinition) {
+	if field == nil {
+		return
+	}
+	directives := field.Directives.ForNames("response")
+	if len(directives) == 0 {
+		return
+	}
+
+	var success *Response
+	for code, response := range op.Responses {
+		if strings.HasPrefix(code, "2") {
+			success = response
+			delete(op.Responses, code)
+		}
+	}
+	for _, directive := range directives {
+		statusArg := directive.Arguments.ForName("status")
+		if statusArg == nil {
+			c.warn("@response on %s has no status", field.Name)
+			continue
+		}
+		status := statusArg.Value.Raw
+		code, err := strconv.Atoi(status)
+		if err != nil || code < 100 || code > 599 {
+			c.warn("@response on %s: invalid status %q", field.Name, status)
+			continue
+		}
+		response := &Response{Description: http.StatusText(code)}
+		if description := directive.Arguments.ForName("description"); description != nil {
+			response.Description = description.Value.Raw
+		}
+		if response.Description == "" {
+			response.Description = "Response " + status
+		}
+		if code >= 200 && code < 300 && code != http.StatusNoContent && success != nil {
+			response.Content = success.Content
+		}
+		op.Responses[status] = response
+	}
+}
+
+// unwrapPayload replaces the response of a REST create/update operation whose
+// mutation returns a payload wrapper (CreateUserPayload { user: User }) with
+// the wrapped resource, when Config.UnwrapPayloads is set
+func (c *Converter) unwrapPayload(op *Operation, field *ast.FieldDefinition, resourceType *ast.Definition) {
+	if !c.config.UnwrapPayloads || resourceType == nil || field.Type.Elem != nil ||
+		!strings.HasSuffix(field.Type.NamedType, "Payload") {
+		return
+	}
+	payload := c.schema.Types[field.Type.NamedType]
+	if payload == nil || payload.Kind != ast.Object {
+		return
+	}
+	for _, payloadField := range payload.Fields {
+		if payloadField.Type.Elem == nil && payloadField.Type.NamedType == resourceType.Name {
+			op.Responses["200"].Content["application/json"].Schema = &Schema{
+				Ref: c.schemaRef(resourceType.Name),
+			}
+			return
+		}
+	}
+}
+
+// returnTypeTag returns the tag for a query/mutation/subscription field: its
+// return type name, or fallback when the field returns a scalar
+func (c *Converter) returnTypeTag(field *ast.FieldDefinition, fallback string) string {
+	typeName := field.Type.Name()
+	if typeDef := c.schema.Types[typeName]; typeDef != nil && typeDef.Kind != ast.Scalar && !isBuiltInType(typeName) && c.typeIncluded(typeName) {
+		return typeName
+	}
+	return fallback
+}
+
+// buildTags lists the tags used by operations, with descriptions taken from
+// the GraphQL type each tag was derived from
+func (c *Converter) buildTags(restPatterns map[string]*RESTPattern) []Tag {
+	seen := make(map[string]bool)
+	for _, pathItem := range c.doc.Paths {
+		for _, po := range pathOperations(pathItem) {
+			for _, tag := range po.Operation.Tags {
+				seen[tag] = true
+			}
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tags := []Tag{}
+	for _, name := range names {
+		tag := Tag{Name: name}
+		if typeDef := c.schema.Types[name]; typeDef != nil {
+			tag.Description = typeDef.Description
+		}
+		for _, resource := range sortedResources(restPatterns) {
+			if pattern := restPatterns[resource]; pattern.Plural == name && pattern.Type != nil {
+				tag.Description = pattern.Type.Description
+			}
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// defaultTagGroups sorts tags into a "Resources" group, the collections of
+// consolidated REST resources, and an "Operations" group for everything else,
+// leaving out a group with no tags
+func defaultTagGroups(tags []Tag, restPatterns map[string]*RESTPattern) []TagGroup {
+	resources := make(map[string]bool)
+	for _, pattern := range restPatterns {
+		resources[pattern.Plural] = true
+	}
+	groups := []TagGroup{}
+	for _, group := range []TagGroup{{Name: "Resources"}, {Name: "Operations"}} {
+		for _, tag := range tags {
+			if resources[tag.Name] == (group.Name == "Resources") {
+				group.Tags = append(group.Tags, tag.Name)
+			}
+		}
+		if len(group.Tags) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// pathOperation pairs an operation with its HTTP method
+type pathOperation struct {
+	Method    string
+	Operation *Operation
+}
+
+// pathOperations returns the operations set on a path item in a fixed method order
+func pathOperations(item *PathItem) []pathOperation {
+	ops := []pathOperation{}
+	for _, po := range []pathOperation{
+		{"get", item.Get},
+		{"put", item.Put},
+		{"post", item.Post},
+		{"delete", item.Delete},
+		{"options", item.Options},
+		{"head", item.Head},
+		{"patch", item.Patch},
+	} {
+		if po.Operation != nil {
+			ops = append(ops, po)
+		}
+	}
+	return ops
+}
+
+// sortedResources returns the resource names of patterns in sorted order
+func sortedResources(patterns map[string]*RESTPattern) []string {
+	resources := make([]string, 0, len(patterns))
+	for resource := range patterns {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+	return resources
+}
+
+// sortedPaths returns the document's path keys in sorted order
+func sortedPaths(doc *OpenAPIDocument) []string {
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// operationID expands Config.OperationIDTemplate for an operation, returning
+// fallback (the built-in operationId) when no template is configured.
+// Placeholders: {method} (HTTP method), {resource} (REST resource, plural for
+// collections, or the field name for other operations), {field} (GraphQL field)
+// and {type} (GraphQL return type), e.g. "{method}_{resource}" -> "get_user"
+func (c *Converter) operationID(fallback, method, resource string, field *ast.FieldDefinition) string {
+	if c.config.OperationIDTemplate == "" {
+		return fallback
+	}
+	fieldName, typeName := "", ""
+	if field != nil {
+		fieldName, typeName = field.Name, field.Type.Name()
+	}
+	return strings.NewReplacer(
+		"{method}", method,
+		"{resource}", resource,
+		"{field}", fieldName,
+		"{type}", typeName,
+	).Replace(c.config.OperationIDTemplate)
+}
+
+// omitOperationIDs clears every operationId in the document
+func (c *Converter) omitOperationIDs() {
+	for _, item := range c.doc.Paths {
+		for _, po := range pathOperations(item) {
+			po.Operation.OperationID = ""
+		}
+	}
+}
+
+// dedupeOperationIDs makes operationIds unique across the document by appending
+// a numeric suffix to later duplicates, visiting paths and methods in a fixed order
+func (c *Converter) dedupeOperationIDs() {
+	used := make(map[string]bool)
+	for _, path := range sortedPaths(c.doc) {
+		for _, po := range pathOperations(c.doc.Paths[path]) {
+			id := po.Operation.OperationID
+			if id == "" {
+				continue
+			}
+			if used[id] {
+				unique := id
+				for n := 2; used[unique]; n++ {
+					unique = fmt.Sprintf("%s%d", id, n)
+				}
+				c.warn("duplicate operationId '%s' on %s %s renamed to '%s'", id, strings.ToUpper(po.Method), path, unique)
+				po.Operation.OperationID = unique
+				id = unique
+			}
+			used[id] = true
+		}
+	}
+}
+
+// responseSchema returns the 200 body schema for a query/mutation return type,
+// collapsing single-field wrapper objects (e.g. { user: User }) when enabled
+func (c *Converter) responseSchema(fieldType *ast.Type) *Schema {
+	if c.config.CollapseSingleFieldResponses && fieldType.Elem == nil {
+		if typeDef := c.schema.Types[fieldType.NamedType]; typeDef != nil &&
+			typeDef.Kind == ast.Object && len(typeDef.Fields) == 1 {
+			return c.convertFieldType(typeDef.Fields[0].Type)
+		}
+	}
+	if c.config.InterfaceAsOneOf {
+		if schema := c.implementersOneOf(fieldType); schema != nil {
+			return schema
+		}
+	}
+	return c.convertFieldType(fieldType)
+}
+
+// implementersOneOf returns a oneOf over the implementing types of an
+// interface-typed (or list of interface) field, or nil for other types
+func (c *Converter) implementersOneOf(fieldType *ast.Type) *Schema {
+	if fieldType.Elem != nil {
+		if items := c.implementersOneOf(fieldType.Elem); items != nil {
+			return &Schema{Type: "array", Items: items}
+		}
+		return nil
+	}
+	typeDef := c.schema.Types[fieldType.NamedType]
+	if typeDef == nil || typeDef.Kind != ast.Interface || !c.typeIncluded(typeDef.Name) {
+		return nil
+	}
+	schema := &Schema{Discriminator: &Discriminator{PropertyName: "__typename", Mapping: map[string]string{}}}
+	for _, implementer := range c.schema.GetPossibleTypes(typeDef) {
+		if !c.typeIncluded(implementer.Name) {
+			continue
+		}
+		ref := c.schemaRef(implementer.Name)
+		schema.OneOf = append(schema.OneOf, &Schema{Ref: ref})
+		schema.Discriminator.Mapping[implementer.Name] = ref
+		c.addTypenameProperty(implementer.Name)
+	}
+	if len(schema.OneOf) == 0 {
+		return nil
+	}
+	return schema
+}
+
+// addTypenameProperty gives typeName's component schema the required
+// __typename property ({enum: [typeName]}) that a discriminator over it reads
+func (c *Converter) addTypenameProperty(typeName string) {
+	schema := c.doc.Components.Schemas[c.schemaName(typeName)]
+	if schema == nil {
+		return
+	}
+	// An implementer of other interfaces is an allOf ending with its own fields
+	if len(schema.AllOf) > 0 {
+		schema = schema.AllOf[len(schema.AllOf)-1]
+	}
+	if _, ok := schema.Properties["__typename"]; ok {
+		return
+	}
+	if schema.Properties == nil {
+		schema.Properties = make(map[string]*Schema)
+	}
+	schema.Properties["__typename"] = &Schema{Type: "string", Enum: []string{typeName}}
+	schema.Required = append([]string{"__typename"}, schema.Required...)
+}
+
+func (c *Converter) convertFieldType(fieldType *ast.Type) *Schema {
+	// Handle lists; [String] items may be null where [String!] items may not
+	if fieldType.Elem != nil {
+		items := c.convertFieldType(fieldType.Elem)
+		if !fieldType.Elem.NonNull {
+			items = c.nullable(items)
+		}
+		return &Schema{
+			Type:  "array",
+			Items: items,
+		}
+	}
+
+	// Handle named types
+	typeName := fieldType.NamedType
+
+	switch typeName {
+	case "Int":
+		return &Schema{Type: "integer", Format: c.intFormat()}
+	case "Float":
+		return &Schema{Type: "number", Format: "double"}
+	case "String":
+		return &Schema{Type: "string"}
+	case "Boolean":
+		return &Schema{Type: "boolean"}
+	case "ID":
+		return c.idSchema()
+	default:
+		// Don't create references to built-in types like Query, Mutation, Subscription
+		// These are GraphQL-specific and don't translate well to REST APIs
+		if isBuiltInType(typeName) {
+			return &Schema{Type: "object"}
+		}
+
+		// Reference to custom type; excluded types degrade to a generic schema
+		if c.schema.Types[typeName] != nil && !c.typeIncluded(typeName) {
+			if c.schema.Types[typeName].Kind == ast.Enum {
+				return &Schema{Type: "string"}
+			}
+			return &Schema{Type: "object"}
+		}
+		if c.schema.Types[typeName] != nil {
+			kind := c.schema.Types[typeName].Kind
+			if kind == ast.Enum && c.config.InlineEnums {
+				return inlineEnum(c.schema.Types[typeName])
+			}
+			if kind == ast.Object || kind == ast.InputObject || kind == ast.Enum || kind == ast.Union || kind == ast.Interface {
+				return &Schema{Ref: c.schemaRef(typeName)}
+			}
+		}
+		if schema := c.mapScalarSchema(typeName); schema != nil {
+			return schema
+		}
+		if schema := c.scalarSchema(typeName); schema != nil {
+			return schema
+		}
+		// Fallback for custom scalars
+		return &Schema{Type: "string"}
+	}
+}
+
+// mapScalarSchema returns the object schema of a scalar listed in
+// Config.MapScalars, or nil for any other type
+func (c *Converter) mapScalarSchema(typeName string) *Schema {
+	valueType, ok := c.config.MapScalars[typeName]
+	if !ok {
+		return nil
+	}
+	schema := &Schema{Type: "object", AdditionalProperties: true}
+	if valueType != "" && valueType != "any" {
+		schema.AdditionalProperties = &Schema{Type: valueType}
+	}
+	return schema
+}
+
+// wellKnownScalars maps conventional numeric and binary scalar names,
+// lowercased, to their Config.ScalarMappings form
+var wellKnownScalars = map[string]string{
+	"bigint":  "integer:int64",
+	"long":    "integer:int64",
+	"int64":   "integer:int64",
+	"decimal": "number",
+	"base64":  "string:byte",
+	"bytes":   "string:byte",
+	"binary":  "string:binary",
+	"upload":  "string:binary",
+}
+
+// scalarSchema returns the schema of a custom scalar mapped by
+// Config.ScalarMappings or recognized by name as numeric or binary, or nil
+func (c *Converter) scalarSchema(typeName string) *Schema {
+	typeDef := c.schema.Types[typeName]
+	if typeDef == nil || typeDef.Kind != ast.Scalar {
+		return nil
+	}
+	mapping, ok := c.config.ScalarMappings[typeName]
+	if !ok {
+		mapping, ok = wellKnownScalars[strings.ToLower(typeName)]
+	}
+	if !ok || mapping == "" {
+		return nil
+	}
+	schemaType, format, _ := strings.Cut(mapping, ":")
+	return &Schema{Type: schemaType, Format: format}
+}
+
+// convertArgumentType converts an argument's type, carrying over its default
+// value, @constraint validations and the format of a @specifiedBy scalar
+func (c *Converter) convertArgumentType(arg *ast.ArgumentDefinition) *Schema {
+	schema := c.convertFieldType(arg.Type)
+	if arg.DefaultValue != nil {
+		schema.Default = valueToInterface(arg.DefaultValue)
+		schema = c.allOfRef(schema)
+	}
+	c.applyConstraintDirectives(schema, arg.Directives)
+	c.applyNonEmptyString(schema, arg.Type)
+	c.applyExample(schema, arg.Directives)
+	if argType := c.schema.Types[arg.Type.Name()]; argType != nil {
+		if specifiedBy := argType.Directives.ForName("specifiedBy"); specifiedBy != nil {
+			if urlArg := specifiedBy.Arguments.ForName("url"); urlArg != nil {
+				if format := c.specifiedByFormat(strings.Trim(urlArg.Value.String(), "\"")); format != "" {
+					schema.Format = format
+				}
+			}
+		}
+	}
+	return schema
+}
+
+// applyNonEmptyString sets minLength: 1 on the schema of a non-null String or
+// string ID under Config.NonNullStringsMinLength1, unless a @constraint gave one
+func (c *Converter) applyNonEmptyString(schema *Schema, fieldType *ast.Type) {
+	if !c.config.NonNullStringsMinLength1 || !fieldType.NonNull || schema.Type != "string" || schema.MinLength != nil {
+		return
+	}
+	if fieldType.NamedType == "String" || fieldType.NamedType == "ID" {
+		minLength := 1
+		schema.MinLength = &minLength
+	}
+}
+
+// applyExample sets the schema example from an @example(value: ...) directive
+func (c *Converter) applyExample(schema *Schema, directives ast.DirectiveList) {
+	if example := directives.ForName("example"); example != nil {
+		if value := example.Arguments.ForName("value"); value != nil {
+			schema.Example = valueToInterface(value.Value)
+		}
+	}
+}
+
+// applyDefault sets the schema default from a @default(value: ...) directive,
+// documenting the value a server fills in for an object field
+func (c *Converter) applyDefault(schema *Schema, directives ast.DirectiveList) {
+	if directive := directives.ForName("default"); directive != nil {
+		if value := directive.Arguments.ForName("value"); value != nil {
+			schema.Default = valueToInterface(value.Value)
+		}
+	}
+}
+
+// applyParameterExample moves an argument's @example from its schema onto the
+// parameter, where Swagger UI pre-fills it, and gives an ID path parameter
+// without one a placeholder
+func (c *Converter) applyParameterExample(param *Parameter, arg *ast.ArgumentDefinition) {
+	if param.Schema != nil && param.Schema.Example != nil {
+		param.Example, param.Schema.Example = param.Schema.Example, nil
+	} else if param.In == "path" && arg.Type.Name() == "ID" {
+		param.Example = c.idExample()
+	}
+}
+
+// directiveExtensions adds to extensions an entry for each directive mapped by
+// Config.DirectiveExtensions: an object of the directive's arguments, or true
+// when it has none
+func (c *Converter) directiveExtensions(extensions map[string]interface{}, directives ast.DirectiveList) map[string]interface{} {
+	for _, directive := range directives {
+		key, ok := c.config.DirectiveExtensions[directive.Name]
+		if !ok {
+			continue
+		}
+		if !strings.HasPrefix(key, "x-") {
+			key = "x-" + key
+		}
+
+		var value interface{} = true
+		if len(directive.Arguments) > 0 {
+			args := make(map[string]interface{})
+			for _, arg := range directive.Arguments {
+				args[arg.Name] = valueToInterface(arg.Value)
+			}
+			value = args
+		}
+		if extensions == nil {
+			extensions = make(map[string]interface{})
+		}
+		extensions[key] = value
+	}
+	return extensions
+}
+
+// valueToInterface converts a GraphQL literal into its JSON equivalent
+func valueToInterface(value *ast.Value) interface{} {
+	switch value.Kind {
+	case ast.IntValue:
+		if v, err := strconv.ParseInt(value.Raw, 10, 64); err == nil {
+			return v
+		}
+		return value.Raw
+	case ast.FloatValue:
+		if v, err := strconv.ParseFloat(value.Raw, 64); err == nil {
+			return v
+		}
+		return value.Raw
+	case ast.BooleanValue:
+		return value.Raw == "true"
+	case ast.NullValue:
+		return nil
+	case ast.ListValue:
+		list := []interface{}{}
+		for _, child := range value.Children {
+			list = append(list, valueToInterface(child.Value))
+		}
+		return list
+	case ast.ObjectValue:
+		obj := make(map[string]interface{})
+		for _, child := range value.Children {
+			obj[child.Name] = valueToInterface(child.Value)
+		}
+		return obj
+	default:
+		// Strings, block strings, enums and variables are emitted as-is
+		return value.Raw
+	}
+}
+
+// applyConstraints copies a validation directive's arguments onto schema, first
+// renaming those listed in aliases to their @constraint names
+func (c *Converter) applyConstraints(schema *Schema, directive *ast.Directive, aliases map[string]string) {
+	var exclusiveMin, exclusiveMax *float64
+	for _, arg := range directive.Arguments {
+		name := arg.Name
+		if alias, ok := aliases[name]; ok {
+			name = alias
+		}
+		switch name {
+		case "minLength", "maxLength":
+			if v := constraintInt(arg.Value); v != nil {
+				if name == "minLength" {
+					schema.MinLength = v
+				} else {
+					schema.MaxLength = v
+				}
+			}
+		case "min", "max":
+			if v := c.constraintNumber(directive, arg); v != nil {
+				if name == "min" {
+					schema.Minimum = v
+				} else {
+					schema.Maximum = v
+				}
+			}
+		case "exclusiveMin", "exclusiveMax":
+			if v := c.constraintNumber(directive, arg); v != nil {
+				if name == "exclusiveMin" {
+					exclusiveMin = v
+				} else {
+					exclusiveMax = v
+				}
+			}
+		case "positive", "nonNegative":
+			// Shorthands for a lower bound of 0; an explicit bound takes precedence
+			if v := constraintBool(arg.Value); v != nil && *v {
+				zero := 0.0
+				if name == "positive" && exclusiveMin == nil {
+					exclusiveMin = &zero
+				} else if name == "nonNegative" && schema.Minimum == nil {
+					schema.Minimum = &zero
+				}
+			}
+		case "multipleOf":
+			if v := c.constraintNumber(directive, arg); v != nil {
+				schema.MultipleOf = v
+			}
+		case "minItems", "maxItems":
+			if v := constraintInt(arg.Value); v != nil {
+				if name == "minItems" {
+					schema.MinItems = v
+				} else {
+					schema.MaxItems = v
+				}
+			}
+		case "uniqueItems":
+			if v := constraintBool(arg.Value); v != nil {
+				schema.UniqueItems = *v
+			}
+		case "pattern":
+			if arg.Value.Kind == ast.StringValue || arg.Value.Kind == ast.BlockValue {
+				schema.Pattern = arg.Value.Raw
+			}
+		case "format":
+			if arg.Value.Kind == ast.StringValue || arg.Value.Kind == ast.BlockValue {
+				schema.Format = arg.Value.Raw
+			}
+		case "enum", "oneOf", "in":
+			// Allowed string values, e.g. @constraint(in: ["red", "green", "blue"])
+			if arg.Value.Kind == ast.ListValue {
+				schema.Enum = nil
+				for _, child := range arg.Value.Children {
+					schema.Enum = append(schema.Enum, child.Value.Raw)
+				}
+			}
+		case "message":
+			if arg.Value.Kind == ast.StringValue || arg.Value.Kind == ast.BlockValue {
+				schema.ErrorMessage = arg.Value.Raw
+			}
+		}
+	}
+	c.applyExclusiveBounds(schema, exclusiveMin, exclusiveMax)
+}
+
+// applyExclusiveBounds sets exclusive bounds the way the target version spells
+// them: as numbers in 3.1, and in 3.0 as minimum/maximum flagged exclusive, in
+// which case an inclusive bound that is already tighter is kept instead
+func (c *Converter) applyExclusiveBounds(schema *Schema, exclusiveMin, exclusiveMax *float64) {
+	if c.isOpenAPI31() {
+		if exclusiveMin != nil {
+			schema.ExclusiveMinimum = *exclusiveMin
+		}
+		if exclusiveMax != nil {
+			schema.ExclusiveMaximum = *exclusiveMax
+		}
+		return
+	}
+	if exclusiveMin != nil && (schema.Minimum == nil || *schema.Minimum <= *exclusiveMin) {
+		schema.Minimum = exclusiveMin
+		schema.ExclusiveMinimum = true
+	}
+	if exclusiveMax != nil && (schema.Maximum == nil || *schema.Maximum >= *exclusiveMax) {
+		schema.Maximum = exclusiveMax
+		schema.ExclusiveMaximum = true
+	}
+}
+
+// constraintAliases maps the arguments of well-known validation directives to
+// @constraint's: @length(min, max) bounds a string, @range(min, max) a number
+var constraintAliases = map[string]map[string]string{
+	"length": {"min": "minLength", "max": "maxLength"},
+	"range":  {"min": "min", "max": "max"},
+}
+
+// applyConstraintDirectives applies the Config.ConstraintDirectiveName directive
+// (default "constraint") and any alias directive (@length, @range, or those in
+// Config.ConstraintAliases) found in directives
+func (c *Converter) applyConstraintDirectives(schema *Schema, directives ast.DirectiveList) {
+	name := c.config.ConstraintDirectiveName
+	if name == "" {
+		name = "constraint"
+	}
+	if directive := directives.ForName(name); directive != nil {
+		c.applyConstraints(schema, directive, nil)
+	}
+
+	for _, directive := range directives {
+		if directive.Name == name {
+			continue
+		}
+		aliases, ok := c.config.ConstraintAliases[directive.Name]
+		if !ok {
+			aliases, ok = constraintAliases[directive.Name]
+		}
+		if ok {
+			c.applyConstraints(schema, directive, aliases)
+		}
+	}
+}
+
+// applySecurity attaches a security requirement to op when field carries the
+// configured auth directive; role/scope arguments become the required scopes
+func (c *Converter) applySecurity(op *Operation, field *ast.FieldDefinition) {
+	if field == nil {
+		return
+	}
+	// @public opts out of the document-wide default with an explicit empty list
+	if len(c.config.DefaultSecurity) > 0 && field.Directives.ForName("public") != nil {
+		op.Security = SecurityRequirements{}
+		return
+	}
+	if c.config.AuthDirective == "" {
+		return
+	}
+	directive := field.Directives.ForName(c.config.AuthDirective)
+	if directive == nil {
+		return
+	}
+
+	roles := []string{}
+	for _, arg := range directive.Arguments {
+		switch arg.Name {
+		case "role", "roles", "scope", "scopes", "requires":
+			switch v := valueToInterface(arg.Value).(type) {
+			case string:
+				roles = append(roles, v)
+			case []interface{}:
+				for _, item := range v {
+					roles = append(roles, fmt.Sprint(item))
+				}
+			}
+		}
+	}
+
+	name, scheme := c.securityScheme()
+	c.addSecurityScheme(name, scheme)
+	// Only OAuth2 and OpenID Connect requirements list scopes; other schemes
+	// must leave them empty, so their roles are documented as x-roles instead
+	scopes := roles
+	if scheme.Type != "oauth2" && scheme.Type != "openIdConnect" {
+		scopes = []string{}
+		if len(roles) > 0 {
+			if op.Extensions == nil {
+				op.Extensions = make(map[string]interface{})
+			}
+			op.Extensions["x-roles"] = roles
+		}
+	}
+	op.Security = append(op.Security, map[string][]string{name: scopes})
+}
+
+// addSecurityScheme registers a scheme under components/securitySchemes
+func (c *Converter) addSecurityScheme(name string, scheme *SecurityScheme) {
+	if c.doc.Components.SecuritySchemes == nil {
+		c.doc.Components.SecuritySchemes = make(map[string]*SecurityScheme)
+	}
+	c.doc.Components.SecuritySchemes[name] = scheme
+}
+
+// addRequestExample gives op's JSON request body a minimal example built
+// from its required fields
+func (c *Converter) addRequestExample(op *Operation) {
+	if op.RequestBody == nil {
+		return
+	}
+	if media := op.RequestBody.Content["application/json"]; media != nil && media.Schema != nil {
+		media.Example = c.minimalExample(media.Schema, map[string]bool{})
+	}
+}
+
+// minimalExample returns a placeholder value for schema, filling objects with
+// their required properties only. visited guards against recursive $refs.
+func (c *Converter) minimalExample(schema *Schema, visited map[string]bool) interface{} {
+	if schema.Example != nil {
+		return schema.Example
+	}
+	if schema.Default != nil {
+		return schema.Default
+	}
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		target := c.doc.Components.Schemas[name]
+		if target == nil || visited[name] {
+			return nil
+		}
+		visited[name] = true
+		defer delete(visited, name)
+		return c.minimalExample(target, visited)
+	}
+	if len(schema.Enum) > 0 {
+		return schema.Enum[0]
+	}
+	if len(schema.OneOf) > 0 {
+		return c.minimalExample(schema.OneOf[0], visited)
+	}
+	if len(schema.AllOf) > 0 {
+		example := map[string]interface{}{}
+		for _, part := range schema.AllOf {
+			if fields, ok := c.minimalExample(part, visited).(map[string]interface{}); ok {
+				for k, v := range fields {
+					example[k] = v
+				}
+			}
+		}
+		return example
+	}
+
+	switch schema.Type {
+	case "object":
+		example := map[string]interface{}{}
+		for _, name := range schema.Required {
+			if prop := schema.Properties[name]; prop != nil {
+				example[name] = c.minimalExample(prop, visited)
+			}
+		}
+		return example
+	case "array":
+		if schema.Items == nil {
+			return []interface{}{}
+		}
+		return []interface{}{c.minimalExample(schema.Items, visited)}
+	case "integer":
+		return 0
+	case "number":
+		return 0.0
+	case "boolean":
+		return false
+	default:
+		switch schema.Format {
+		case "date-time":
+			return "2024-01-01T00:00:00Z"
+		case "date":
+			return "2024-01-01"
+		case "email":
+			return "user@example.com"
+		case "uuid":
+			return "00000000-0000-0000-0000-000000000000"
+		case "uri", "url":
+			return "https://example.com"
+		}
+		return "string"
+	}
+}
+
+// addContentTypes offers op's JSON request and response bodies under each of
+// Config.ResponseContentTypes instead of application/json alone
+func (c *Converter) addContentTypes(op *Operation) {
+	if op.RequestBody != nil {
+		c.expandContentTypes(op.RequestBody.Content)
+	}
+	for _, response := range op.Responses {
+		c.expandContentTypes(response.Content)
+	}
+}
+
+// expandContentTypes replaces the application/json entry of content with one
+// per Config.ResponseContentTypes
+func (c *Converter) expandContentTypes(content map[string]*MediaType) {
+	types := c.config.ResponseContentTypes
+	if len(types) == 0 || (len(types) == 1 && types[0] == "application/json") {
+		return
+	}
+	media := content["application/json"]
+	if media == nil {
+		return
+	}
+	delete(content, "application/json")
+	for _, contentType := range types {
+		expanded := &MediaType{Schema: media.Schema}
+		// The example is JSON, which would misrepresent an XML or text body
+		if isJSONMediaType(contentType) {
+			expanded.Example = media.Example
+		}
+		content[contentType] = expanded
+	}
+}
+
+// isJSONMediaType reports whether contentType is application/json or a JSON
+// based type such as application/problem+json
+func isJSONMediaType(contentType string) bool {
+	mediaType := strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+	return strings.HasSuffix(mediaType, "/json") || strings.HasSuffix(mediaType, "+json")
+}
+
+// addFieldExamples gives op's JSON request and response bodies without an
+// example one assembled from the @example values of the fields they contain
+func (c *Converter) addFieldExamples(op *Operation) {
+	media := []*MediaType{}
+	if op.RequestBody != nil {
+		media = append(media, op.RequestBody.Content["application/json"])
+	}
+	for _, response := range op.Responses {
+		media = append(media, response.Content["application/json"])
+	}
+	for _, m := range media {
+		if m != nil && m.Schema != nil && m.Example == nil {
+			m.Example = c.fieldExample(m.Schema, map[string]bool{})
+		}
+	}
+}
+
+// fieldExample composes an example for schema from the examples of its
+// properties, returning nil when none of them has one
+func (c *Converter) fieldExample(schema *Schema, visited map[string]bool) interface{} {
+	if schema.Example != nil {
+		return schema.Example
+	}
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		target := c.doc.Components.Schemas[name]
+		if target == nil || visited[name] {
+			return nil
+		}
+		visited[name] = true
+		defer delete(visited, name)
+		return c.fieldExample(target, visited)
+	}
+	if schema.Items != nil {
+		if item := c.fieldExample(schema.Items, visited); item != nil {
+			return []interface{}{item}
+		}
+		return nil
+	}
+
+	if len(schema.AllOf) == 1 && len(schema.Properties) == 0 {
+		// A wrapped $ref, e.g. a nullable enum
+		return c.fieldExample(schema.AllOf[0], visited)
+	}
+
+	example := map[string]interface{}{}
+	for _, part := range schema.AllOf {
+		if fields, ok := c.fieldExample(part, visited).(map[string]interface{}); ok {
+			for k, v := range fields {
+				example[k] = v
+			}
+		}
+	}
+	for name, prop := range schema.Properties {
+		if value := c.fieldExample(prop, visited); value != nil {
+			example[name] = value
+		}
+	}
+	if len(example) == 0 {
+		return nil
+	}
+	return example
+}
+
+// addAuthResponses documents 401 and 403 responses on every operation that
+// ends up with a security requirement
+func (c *Converter) addAuthResponses() {
+	for _, path := range sortedPaths(c.doc) {
+		for _, po := range pathOperations(c.doc.Paths[path]) {
+			if !c.isSecured(po.Operation) {
+				continue
+			}
+			po.Operation.Responses["401"] = c.errorResponse("Unauthorized")
+			po.Operation.Responses["403"] = c.errorResponse("Forbidden")
+		}
+	}
+}
+
+// addProbeOperations adds the HEAD and OPTIONS operations enabled by
+// Config.GenerateHead/GenerateOptions to each REST resource's collection and item paths
+func (c *Converter) addProbeOperations(restPatterns map[string]*RESTPattern) {
+	if !c.config.GenerateHead && !c.config.GenerateOptions {
+		return
+	}
+	for _, resource := range sortedResources(restPatterns) {
+		pattern := restPatterns[resource]
+		collectionPath := c.collectionPath(pattern)
+		for _, path := range []string{collectionPath, c.itemPath(pattern)} {
+			item := c.doc.Paths[path]
+			if item == nil {
+				continue
+			}
+			name := resource
+			if path == collectionPath {
+				name = pattern.Plural
+			}
+
+			if c.config.GenerateHead && item.Get != nil && item.Head == nil {
+				item.Head = &Operation{
+					OperationID: c.operationID("head"+c.capitalize(name), "head", name, nil),
+					Tags:        item.Get.Tags,
+					Summary:     "Check " + name,
+					Parameters:  item.Get.Parameters,
+					Security:    item.Get.Security,
+					Responses: map[string]*Response{
+						"200": {Description: "Same headers as GET, without a body"},
+					},
+				}
+			}
+
+			if c.config.GenerateOptions && item.Options == nil {
+				methods := []string{}
+				for _, po := range pathOperations(item) {
+					methods = append(methods, strings.ToUpper(po.Method))
+				}
+				allowed := strings.Join(append(methods, "OPTIONS"), ", ")
+				op := &Operation{
+					OperationID: c.operationID("options"+c.capitalize(name), "options", name, nil),
+					Summary:     "Allowed methods for " + name,
+					Responses: map[string]*Response{
+						"204": {
+							Description: "Allowed methods: " + allowed,
+							Headers: map[string]*Header{
+								"Allow": {Description: allowed, Schema: &Schema{Type: "string"}},
+							},
+						},
+					},
+				}
+				if len(methods) > 0 {
+					op.Tags = pathOperations(item)[0].Operation.Tags
+				}
+				op.Parameters = c.pathParameters(item)
+				// Preflight requests carry no credentials
+				if c.doc.Security != nil {
+					op.Security = SecurityRequirements{}
+				}
+				item.Options = op
+			}
+		}
+	}
+}
+
+// pathParameters returns the path parameters declared by the operations on
+// item; they are shared by every method on the path
+func (c *Converter) pathParameters(item *PathItem) []*Parameter {
+	if len(item.Parameters) > 0 {
+		return item.Parameters
+	}
+	for _, po := range pathOperations(item) {
+		var params []*Parameter
+		for _, param := range po.Operation.Parameters {
+			if resolved := resolveParameter(c.doc, param); resolved != nil && resolved.In == "path" {
+				params = append(params, param)
+			}
+		}
+		if len(params) > 0 {
+			return params
+		}
+	}
+	return nil
+}
+
+// hoistPathParameters declares the path parameters that every operation on a
+// path shares once on the path item, instead of repeating them per method
+func (c *Converter) hoistPathParameters() {
+	for _, item := range c.doc.Paths {
+		ops := pathOperations(item)
+		if len(ops) < 2 {
+			continue
+		}
+		for _, param := range ops[0].Operation.Parameters {
+			if resolved := resolveParameter(c.doc, param); resolved == nil || resolved.In != "path" {
+				continue
+			}
+			shared := true
+			for _, po := range ops[1:] {
+				if indexOfParameter(po.Operation.Parameters, param) < 0 {
+					shared = false
+				}
+			}
+			if shared {
+				item.Parameters = append(item.Parameters, param)
+			}
+		}
+		for _, param := range item.Parameters {
+			for _, po := range ops {
+				i := indexOfParameter(po.Operation.Parameters, param)
+				po.Operation.Parameters = append(po.Operation.Parameters[:i:i], po.Operation.Parameters[i+1:]...)
+			}
+		}
+	}
+}
+
+// indexOfParameter returns the position of a parameter identical to param in
+// params, or -1
+func indexOfParameter(params []*Parameter, param *Parameter) int {
+	want, _ := json.Marshal(param)
+	for i, p := range params {
+		if got, _ := json.Marshal(p); p == param || string(got) == string(want) {
+			return i
+		}
+	}
+	return -1
+}
+
+// corsHeaders are the response headers documented on CORS preflight responses
+var corsHeaders = map[string]Header{
+	"Access-Control-Allow-Origin":  {Description: "Origin allowed to make the request", Schema: &Schema{Type: "string"}},
+	"Access-Control-Allow-Methods": {Description: "Methods allowed on this path", Schema: &Schema{Type: "string"}},
+	"Access-Control-Allow-Headers": {Description: "Request headers allowed on this path", Schema: &Schema{Type: "string"}},
+	"Access-Control-Max-Age":       {Description: "Seconds the preflight response may be cached", Schema: &Schema{Type: "integer"}},
+}
+
+// addGraphQLEndpoint documents the schema's own POST /graphql endpoint next to
+// the REST surface, as enabled by Config.IncludeGraphQLEndpoint
+func (c *Converter) addGraphQLEndpoint() {
+	c.setOperation(c.addPrefix("/graphql"), "post", &Operation{
+		OperationID: "graphql",
+		Tags:        []string{"GraphQL"},
+		Summary:     "Execute a GraphQL operation",
+		RequestBody: &RequestBody{
+			Required: true,
+			Content: Content{
+				"application/json": {
+					Schema: &Schema{
+						Type: "object",
+						Properties: map[string]*Schema{
+							"query":         {Type: "string", Description: "GraphQL document"},
+							"variables":     {Type: "object", AdditionalProperties: true},
+							"operationName": {Type: "string", Description: "Operation to run when the document has several"},
+						},
+						Required: []string{"query"},
+					},
+				},
+			},
+		},
 		Responses: map[string]*Response{
 			"200": {
-				Description: "Successful response",
-				Content: map[string]*MediaType{
+				Description: "GraphQL response",
+				Content: Content{
 					"application/json": {
-						Schema: c.convertFieldType(field.Type),
+						Schema: &Schema{
+							Type: "object",
+							Properties: map[string]*Schema{
+								"data":   {Type: "object", AdditionalProperties: true},
+								"errors": {Type: "array", Items: &Schema{Type: "object", AdditionalProperties: true}},
+							},
+						},
 					},
 				},
 			},
 		},
+	})
+}
+
+// addCORSPreflight documents a CORS preflight OPTIONS operation on every path
+// that has at least one other operation, as enabled by Config.DocumentCORS. A
+// path that already has an OPTIONS operation gets the CORS headers added to it.
+func (c *Converter) addCORSPreflight() {
+	if !c.config.DocumentCORS {
+		return
 	}
+	for _, path := range sortedPaths(c.doc) {
+		item := c.doc.Paths[path]
+		ops := pathOperations(item)
+		if len(ops) == 0 {
+			continue
+		}
 
-	// Handle deprecated directive
-	deprecated := field.Directives.ForName("deprecated")
-	if deprecated != nil {
-		op.Deprecated = true
-		if reason := deprecated.Arguments.ForName("reason"); reason != nil {
-			depReason := strings.Trim(reason.Value.String(), "\"")
-			op.Summary = "DEPRECATED: " + depReason
-			if op.Description != "" {
-				op.Description = "DEPRECATED: " + depReason + "\n\n" + op.Description
-			} else {
-				op.Description = "DEPRECATED: " + depReason
+		if item.Options == nil {
+			methods := []string{}
+			for _, po := range ops {
+				methods = append(methods, strings.ToUpper(po.Method))
 			}
+			item.Options = &Operation{
+				OperationID: "preflight" + c.capitalize(ops[0].Operation.OperationID),
+				Tags:        ops[0].Operation.Tags,
+				Summary:     "CORS preflight",
+				Description: "Allowed methods: " + strings.Join(append(methods, "OPTIONS"), ", "),
+				Parameters:  c.pathParameters(item),
+				Responses: map[string]*Response{
+					"204": {Description: "Preflight accepted"},
+				},
+			}
+			// Preflight requests carry no credentials
+			if c.doc.Security != nil {
+				item.Options.Security = SecurityRequirements{}
+			}
+		} else if item.Options.Responses["204"] == nil {
+			continue
+		} else if len(ops) == 1 {
+			continue // OPTIONS is the only operation on the path
 		}
-	}
 
-	// Convert arguments to request body
-	if len(field.Arguments) > 0 {
-		bodySchema := &Schema{
-			Type:       "object",
-			Properties: make(map[string]*Schema),
-			Required:   []string{},
+		response := item.Options.Responses["204"]
+		if response.Headers == nil {
+			response.Headers = make(map[string]*Header)
 		}
-
-		for _, arg := range field.Arguments {
-			propSchema := c.convertFieldType(arg.Type)
-			if arg.Description != "" {
-				propSchema.Description = arg.Description
-			}
-			bodySchema.Properties[arg.Name] = propSchema
-			if arg.Type.NonNull {
-				bodySchema.Required = append(bodySchema.Required, arg.Name)
+		for name, header := range corsHeaders {
+			if response.Headers[name] == nil {
+				response.Headers[name] = &Header{Description: header.Description, Schema: &Schema{Type: header.Schema.Type}}
 			}
 		}
+	}
+}
 
-		op.RequestBody = &RequestBody{
-			Required: true,
-			Content: map[string]*MediaType{
-				"application/json": {
-					Schema: bodySchema,
-				},
-			},
+// isSecured reports whether op requires authentication, either through its own
+// requirement or the document default it does not override
+func (c *Converter) isSecured(op *Operation) bool {
+	requirements := op.Security
+	if requirements == nil {
+		requirements = c.doc.Security
+	}
+	for _, requirement := range requirements {
+		if len(requirement) > 0 {
+			return true
 		}
 	}
+	return false
+}
 
-	return op
+// errorResponse returns a reference to the components/responses entry named
+// after description (e.g. Unauthorized), defining it and the shared error
+// schema it responds with on first use
+func (c *Converter) errorResponse(description string) *Response {
+	key := strings.ReplaceAll(description, " ", "")
+	if c.doc.Components.Responses == nil {
+		c.doc.Components.Responses = make(map[string]*Response)
+	}
+	if c.doc.Components.Responses[key] == nil {
+		c.doc.Components.Responses[key] = c.errorResponseBody(description)
+	}
+	return &Response{Ref: "#/components/responses/" + key}
 }
 
-func (c *Converter) convertFieldType(fieldType *ast.Type) *Schema {
-	// Handle lists
-	if fieldType.Elem != nil {
-		return &Schema{
-			Type:  "array",
-			Items: c.convertFieldType(fieldType.Elem),
+// errorResponseBody returns an error response with the shared error schema,
+// defining it under components/schemas on first use
+func (c *Converter) errorResponseBody(description string) *Response {
+	name := c.schemaName("Error")
+	if c.schema.Types["Error"] != nil {
+		// The GraphQL schema already has an Error type
+		name = c.schemaName("ErrorResponse")
+	}
+	if c.doc.Components.Schemas[name] == nil {
+		c.doc.Components.Schemas[name] = &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"message": {Type: "string"},
+			},
+			Required: []string{"message"},
 		}
 	}
+	return &Response{
+		Description: description,
+		Content: map[string]*MediaType{
+			"application/json": {
+				Schema: &Schema{Ref: "#/components/schemas/" + name},
+			},
+		},
+	}
+}
 
-	// Handle named types
-	typeName := fieldType.NamedType
-
-	switch typeName {
-	case "Int":
-		return &Schema{Type: "integer", Format: "int32"}
-	case "Float":
-		return &Schema{Type: "number", Format: "double"}
-	case "String":
-		return &Schema{Type: "string"}
-	case "Boolean":
-		return &Schema{Type: "boolean"}
-	case "ID":
-		return &Schema{Type: "string"}
+// securityScheme returns the component name and definition for Config.SecuritySchemeType
+func (c *Converter) securityScheme() (string, *SecurityScheme) {
+	switch c.config.SecuritySchemeType {
+	case "basic":
+		return "basicAuth", &SecurityScheme{Type: "http", Scheme: "basic"}
+	case "apiKey":
+		return "apiKeyAuth", &SecurityScheme{Type: "apiKey", In: "header", Name: "X-API-Key"}
 	default:
-		// Don't create references to built-in types like Query, Mutation, Subscription
-		// These are GraphQL-specific and don't translate well to REST APIs
-		if isBuiltInType(typeName) {
-			return &Schema{Type: "object"}
-		}
+		return "bearerAuth", &SecurityScheme{Type: "http", Scheme: "bearer"}
+	}
+}
 
-		// Reference to custom type
-		if c.schema.Types[typeName] != nil {
-			kind := c.schema.Types[typeName].Kind
-			if kind == ast.Object || kind == ast.InputObject || kind == ast.Enum || kind == ast.Union || kind == ast.Interface {
-				return &Schema{Ref: "#/components/schemas/" + typeName}
-			}
+// constraintInt coerces a @constraint argument to an int. Numeric strings
+// (minLength: "3") are accepted; null and non-integral values are ignored.
+func constraintInt(value *ast.Value) *int {
+	switch value.Kind {
+	case ast.IntValue, ast.StringValue:
+		if v, err := strconv.Atoi(constraintLiteral(value.Raw)); err == nil {
+			return &v
 		}
-		// Fallback for custom scalars
-		return &Schema{Type: "string"}
 	}
+	return nil
 }
 
-func (c *Converter) applyConstraints(schema *Schema, directive *ast.Directive) {
-	for _, arg := range directive.Arguments {
-		raw := strings.Trim(arg.Value.Raw, "\"")
-		switch arg.Name {
-		case "minLength", "maxLength":
-			if v := parseInt(raw); v != nil {
-				if arg.Name == "minLength" {
-					schema.MinLength = v
-				} else {
-					schema.MaxLength = v
-				}
-			}
-		case "min", "max":
-			if v := parseFloat(raw); v != nil {
-				if arg.Name == "min" {
-					schema.Minimum = v
-				} else {
-					schema.Maximum = v
-				}
-			}
-		case "pattern":
-			schema.Pattern = raw
-		case "format":
-			schema.Format = raw
+// constraintFloat coerces a @constraint argument to a number, accepting ints,
+// floats and numeric strings
+func constraintFloat(value *ast.Value) *float64 {
+	switch value.Kind {
+	case ast.IntValue, ast.FloatValue, ast.StringValue:
+		if v, err := strconv.ParseFloat(constraintLiteral(value.Raw), 64); err == nil {
+			return &v
 		}
 	}
+	return nil
 }
 
-func parseInt(s string) *int {
-	if v, err := fmt.Sscanf(s, "%d", new(int)); err == nil && v == 1 {
-		var result int
-		fmt.Sscanf(s, "%d", &result)
-		return &result
+// constraintNumber is constraintFloat for an argument of directive, ignoring
+// with a warning a value such as "NaN" or "Inf" that JSON can't represent
+func (c *Converter) constraintNumber(directive *ast.Directive, arg *ast.Argument) *float64 {
+	v := constraintFloat(arg.Value)
+	if v != nil && (math.IsNaN(*v) || math.IsInf(*v, 0)) {
+		c.warn("@%s(%s: %s) is not a finite number, ignoring it", directive.Name, arg.Name, arg.Value.String())
+		return nil
 	}
-	return nil
+	return v
 }
 
-func parseFloat(s string) *float64 {
-	if v, err := fmt.Sscanf(s, "%f", new(float64)); err == nil && v == 1 {
-		var result float64
-		fmt.Sscanf(s, "%f", &result)
-		return &result
+// constraintLiteral strips the whitespace and one level of quotes around a
+// constraint string (e.g. "\"20\"" -> 20); strconv then accepts only a whole
+// number, so "10abc" yields no constraint rather than 10
+func constraintLiteral(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if unquoted, err := strconv.Unquote(raw); err == nil {
+		return strings.TrimSpace(unquoted)
+	}
+	return raw
+}
+
+// constraintBool coerces a @constraint argument to a boolean, accepting
+// true/false literals and their string forms only (not "1" or "t")
+func constraintBool(value *ast.Value) *bool {
+	switch value.Kind {
+	case ast.BooleanValue, ast.StringValue, ast.EnumValue:
+		switch constraintLiteral(value.Raw) {
+		case "true":
+			v := true
+			return &v
+		case "false":
+			v := false
+			return &v
+		}
 	}
 	return nil
 }
 
 func (c *Converter) applySpecifiedBy(schema *Schema, url string) {
-	// Try to infer format from common URLs
-	if strings.Contains(url, "rfc4122") || strings.Contains(strings.ToLower(url), "uuid") {
-		schema.Format = "uuid"
-	} else if strings.Contains(strings.ToLower(url), "date-time") {
-		schema.Format = "date-time"
+	if format := c.specifiedByFormat(url); format != "" {
+		schema.Format = format
 	}
 
 	if schema.Description != "" {
@@ -1023,19 +4037,266 @@ func (c *Converter) applySpecifiedBy(schema *Schema, url string) {
 	}
 }
 
+// specifiedByFormats maps well-known @specifiedBy URL fragments to OpenAPI
+// formats, in match order ("date-time" must win over plain dates)
+var specifiedByFormats = []struct{ fragment, format string }{
+	{"rfc4122", "uuid"},
+	{"uuid", "uuid"},
+	{"date-time", "date-time"},
+	{"full-date", "date"},
+	{"local-date", "date"},
+	{"rfc5321", "email"},
+	{"rfc5322", "email"},
+	{"email", "email"},
+	{"rfc3986", "uri"},
+	{"bigint", "int64"},
+}
+
+// specifiedByFormat infers an OpenAPI format from a @specifiedBy URL, trying
+// Config.SpecifiedByFormats (longest fragment first) before the built-in table
+func (c *Converter) specifiedByFormat(url string) string {
+	url = strings.ToLower(url)
+	fragments := make([]string, 0, len(c.config.SpecifiedByFormats))
+	for fragment := range c.config.SpecifiedByFormats {
+		fragments = append(fragments, fragment)
+	}
+	sort.Slice(fragments, func(i, j int) bool {
+		if len(fragments[i]) != len(fragments[j]) {
+			return len(fragments[i]) > len(fragments[j])
+		}
+		return fragments[i] < fragments[j]
+	})
+	for _, fragment := range fragments {
+		if strings.Contains(url, strings.ToLower(fragment)) {
+			return c.config.SpecifiedByFormats[fragment]
+		}
+	}
+	for _, known := range specifiedByFormats {
+		if strings.Contains(url, known.fragment) {
+			return known.format
+		}
+	}
+	return ""
+}
+
+func (c *Converter) openAPIVersion() string {
+	if c.config.OpenAPIVersion != "" {
+		return c.config.OpenAPIVersion
+	}
+	return "3.0.0"
+}
+
+func (c *Converter) isOpenAPI31() bool {
+	return strings.HasPrefix(c.openAPIVersion(), "3.1")
+}
+
+// nullableOneOf wraps a schema as oneOf [schema, {type: null}], keeping the
+// description and deprecation on the outer schema for readability
+func nullableOneOf(schema *Schema) *Schema {
+	wrapped := &Schema{
+		Description: schema.Description,
+		Deprecated:  schema.Deprecated,
+	}
+	inner := *schema
+	inner.Description = ""
+	inner.Deprecated = false
+	wrapped.OneOf = []*Schema{&inner, {Type: "null"}}
+	return wrapped
+}
+
+// nullable marks schema as accepting null: in 3.1 type: [T, "null"], or a
+// {type: null} oneOf branch under Config.NullableAsOneOf and for schemas
+// without a plain type; nullable: true in 3.0 (moving a $ref into allOf so the
+// flag isn't ignored)
+func (c *Converter) nullable(schema *Schema) *Schema {
+	if c.isOpenAPI31() {
+		if c.config.NullableAsOneOf || schema.Type == "" || len(schema.Enum) > 0 {
+			return nullableOneOf(schema)
+		}
+		schema.NullableType = true
+		return schema
+	}
+	if schema.Ref != "" {
+		return nullableAllOf(schema)
+	}
+	schema.Nullable = true
+	return schema
+}
+
+// nullableAllOf wraps a $ref schema as {nullable: true, allOf: [$ref]}, since
+// OpenAPI 3.0 ignores siblings of a bare $ref
+func nullableAllOf(schema *Schema) *Schema {
+	wrapped := *schema
+	wrapped.Ref = ""
+	wrapped.Nullable = true
+	wrapped.AllOf = []*Schema{{Ref: schema.Ref}}
+	return &wrapped
+}
+
+// allOfRef moves a $ref into allOf under OpenAPI 3.0, which ignores keywords
+// such as default or readOnly set beside a bare $ref; 3.1 honours them as they are
+func (c *Converter) allOfRef(schema *Schema) *Schema {
+	if schema.Ref == "" || c.isOpenAPI31() {
+		return schema
+	}
+	wrapped := *schema
+	wrapped.Ref = ""
+	wrapped.AllOf = []*Schema{{Ref: schema.Ref}}
+	return &wrapped
+}
+
+// idSchema returns the schema for GraphQL's ID type
+func (c *Converter) idSchema() *Schema {
+	if c.config.TreatIDAsInteger || c.config.IDFormat == "int64" {
+		return &Schema{Type: "integer", Format: "int64"}
+	}
+	if c.config.IDFormat == "uuid" {
+		return &Schema{Type: "string", Format: "uuid"}
+	}
+	return &Schema{Type: "string"}
+}
+
+// idExample returns the placeholder example of an ID path parameter
+func (c *Converter) idExample() interface{} {
+	if c.config.TreatIDAsInteger || c.config.IDFormat == "int64" {
+		return 1
+	}
+	return "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+}
+
+// strictObject disallows undeclared properties on schema under Config.StrictObjects
+func (c *Converter) strictObject(schema *Schema) *Schema {
+	if c.config.StrictObjects {
+		schema.AdditionalProperties = false
+	}
+	return schema
+}
+
+// intFormat returns the format for GraphQL's Int type
+func (c *Converter) intFormat() string {
+	if c.config.IntFormat == "int64" {
+		return "int64"
+	}
+	return "int32"
+}
+
+// isEnumType reports whether typeName names an enum defined in the schema
+func (c *Converter) isEnumType(typeName string) bool {
+	typeDef := c.schema.Types[typeName]
+	return typeDef != nil && typeDef.Kind == ast.Enum
+}
+
+// schemaName returns the components/schemas key for a GraphQL type name
+func (c *Converter) schemaName(typeName string) string {
+	if key, ok := c.inputNames[typeName]; ok {
+		return key
+	}
+	if renamed, ok := c.config.SchemaNameMap[typeName]; ok {
+		typeName = renamed
+	}
+	return c.config.SchemaNamePrefix + typeName + c.config.SchemaNameSuffix
+}
+
+// namespaceInputs finds input objects whose component key an object, interface,
+// union or enum already has (e.g. both renamed to "User" by SchemaNameMap) and
+// gives them an Input-suffixed key, so neither schema overwrites the other
+func (c *Converter) namespaceInputs() map[string]string {
+	taken := make(map[string]string) // component key -> output type name
+	for name, typeDef := range c.schema.Types {
+		if typeDef.Kind != ast.InputObject && typeDef.Kind != ast.Scalar && !isBuiltInType(name) && c.typeIncluded(name) {
+			taken[c.schemaName(name)] = name
+		}
+	}
+
+	inputs := []string{}
+	for name, typeDef := range c.schema.Types {
+		if typeDef.Kind == ast.InputObject && c.typeIncluded(name) {
+			inputs = append(inputs, name)
+		}
+	}
+	sort.Strings(inputs)
+
+	renames := make(map[string]string)
+	for _, name := range inputs {
+		key := c.schemaName(name)
+		other, collides := taken[key]
+		if !collides {
+			taken[key] = name
+			continue
+		}
+		renamed := key + "Input"
+		for n := 2; taken[renamed] != ""; n++ {
+			renamed = fmt.Sprintf("%sInput%d", key, n)
+		}
+		c.warn("input %s and type %s share the schema name %s, naming the input %s", name, other, key, renamed)
+		taken[renamed] = name
+		renames[name] = renamed
+	}
+	return renames
+}
+
+// schemaRef returns the $ref pointing at the component schema for a GraphQL type name
+func (c *Converter) schemaRef(typeName string) string {
+	return "#/components/schemas/" + c.schemaName(typeName)
+}
+
+// addPrefix builds the path key for a path derived from GraphQL names,
+// applying Config.PathCase and then the path prefix
 func (c *Converter) addPrefix(path string) string {
-	if c.config.PathPrefix != "" {
+	return c.prefixPath(c.casePath(path))
+}
+
+// prefixPath prepends Config.PathPrefix unless it belongs in the server URL
+func (c *Converter) prefixPath(path string) string {
+	if c.config.PathPrefix != "" && !c.config.PrefixInServerURL {
 		return c.config.PathPrefix + path
 	}
 	return path
 }
 
+// casePath converts each path segment to Config.PathCase, leaving {param}
+// segments intact (e.g. /productCategories/{id} -> /product-categories/{id})
+func (c *Converter) casePath(path string) string {
+	var sep string
+	switch c.config.PathCase {
+	case "kebab":
+		sep = "-"
+	case "snake":
+		sep = "_"
+	default:
+		return path
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, "{") {
+			segments[i] = splitCamel(segment, sep)
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// splitCamel lowercases a camelCase word, joining its parts with sep
+// (e.g. "getHTTPStatus" -> "get-http-status")
+func splitCamel(s string, sep string) string {
+	runes := []rune(s)
+	var result []rune
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				result = append(result, []rune(sep)...)
+			}
+		}
+		result = append(result, unicode.ToLower(r))
+	}
+	return string(result)
+}
+
 func (c *Converter) pluralize(word string) string {
 	// Check custom plurals (suffix match)
-	for suffix, replacement := range c.config.CustomPlurals {
-		if strings.HasSuffix(word, suffix) {
-			return strings.TrimSuffix(word, suffix) + replacement
-		}
+	if suffix, replacement, ok := longestSuffix(word, c.config.CustomPlurals); ok {
+		return strings.TrimSuffix(word, suffix) + replacement
 	}
 
 	// Check if word ends with any suffix that requires "es"
@@ -1056,11 +4317,12 @@ func (c *Converter) pluralize(word string) string {
 }
 
 func (c *Converter) singularize(word string) string {
-	// Check custom plurals in reverse
-	for suffix, replacement := range c.config.CustomPlurals {
-		if strings.HasSuffix(word, replacement) {
-			return strings.TrimSuffix(word, replacement) + suffix
-		}
+	// Check custom singulars, then custom plurals in reverse (suffix match)
+	if suffix, replacement, ok := longestSuffix(word, c.config.CustomSingulars); ok {
+		return strings.TrimSuffix(word, suffix) + replacement
+	}
+	if suffix, replacement, ok := longestSuffix(word, reversePlurals(c.config.CustomPlurals)); ok {
+		return strings.TrimSuffix(word, suffix) + replacement
 	}
 
 	// Reverse "ies" -> "y" conversion
@@ -1072,9 +4334,19 @@ func (c *Converter) singularize(word string) string {
 	if strings.HasSuffix(word, "es") && len(word) > 2 {
 		base := word[:len(word)-2]
 		for _, suffix := range c.config.PluralizeSuffixesES {
-			if strings.HasSuffix(base, suffix) {
-				return base
+			if !strings.HasSuffix(base, suffix) {
+				continue
+			}
+			// Of words ending in a single s only -ss and -us ones take "es"
+			// (addresses, buses); cases and databases just take an "s"
+			if suffix == "s" && !strings.HasSuffix(base, "ss") && !strings.HasSuffix(base, "us") {
+				return word[:len(word)-1]
 			}
+			return base
+		}
+		// Consonant + o takes "es" (heroes, potatoes); short words like shoes and toes don't
+		if len(base) > 3 && strings.HasSuffix(base, "o") && !isVowel(rune(base[len(base)-2])) {
+			return base
 		}
 		// Not a special case, just remove "s"
 		return word[:len(word)-1]
@@ -1088,6 +4360,34 @@ func (c *Converter) singularize(word string) string {
 	return word
 }
 
+// longestSuffix returns the longest key of replacements that word ends with,
+// and its replacement, so overlapping entries ("man", "woman") match the same
+// way whatever the map's iteration order
+func longestSuffix(word string, replacements map[string]string) (string, string, bool) {
+	best := ""
+	found := false
+	for suffix := range replacements {
+		if strings.HasSuffix(word, suffix) && (!found || len(suffix) > len(best)) {
+			best = suffix
+			found = true
+		}
+	}
+	return best, replacements[best], found
+}
+
+// reversePlurals maps each custom plural back to its singular, so that
+// singularize(pluralize(word)) round-trips; when two singulars share a plural
+// the alphabetically first wins
+func reversePlurals(plurals map[string]string) map[string]string {
+	singulars := make(map[string]string, len(plurals))
+	for singular, plural := range plurals {
+		if existing, ok := singulars[plural]; !ok || singular < existing {
+			singulars[plural] = singular
+		}
+	}
+	return singulars
+}
+
 func (c *Converter) capitalize(s string) string {
 	if s == "" {
 		return s
@@ -1104,7 +4404,7 @@ func (c *Converter) uncapitalize(s string) string {
 
 func (c *Converter) extractSchemaDescription(schemaSource string) string {
 	// Extract description from top of schema (before any type definitions)
-	// Look for """ ... """ or # comments at the start
+	// Look for """ ... """ or a run of # comments at the start
 	lines := strings.Split(schemaSource, "\n")
 	var desc []string
 	inBlockComment := false
@@ -1138,6 +4438,16 @@ func (c *Converter) extractSchemaDescription(schemaSource string) string {
 			continue
 		}
 
+		// A run of leading # comment lines also forms the description,
+		// with bare # lines separating paragraphs; # import lines are not prose
+		if importComment.MatchString(trimmed) {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "#") {
+			desc = append(desc, strings.TrimSpace(strings.TrimPrefix(trimmed, "#")))
+			continue
+		}
+
 		// Stop at first type definition
 		if strings.HasPrefix(trimmed, "type ") ||
 			strings.HasPrefix(trimmed, "interface ") ||
@@ -1211,17 +4521,33 @@ func (c *Converter) addFieldNamePrefix(fieldName string, description string) str
 }
 
 func (c *Converter) splitDescription(text string) (summary string, description string) {
+	text = normalizeDescription(text)
 	if text == "" {
 		return "", ""
 	}
 
+	// Only the prose opening the first paragraph is considered for the summary,
+	// with its line breaks treated as spaces; a Markdown list or code block ends it
+	prose := []string{}
+	for _, line := range strings.Split(strings.SplitN(text, "\n\n", 2)[0], "\n") {
+		if markdownBlock.MatchString(line) {
+			break
+		}
+		prose = append(prose, line)
+	}
+	if len(prose) == 0 {
+		// The text opens with a list or code block, which has no sentence to take
+		return "", text
+	}
+	firstParagraph := strings.Join(prose, " ")
+
 	// Find first sentence-ending punctuation (. ! ? : ; -)
 	punctuations := []string{". ", "! ", "? ", ": ", "; ", " - "}
 	firstPunctIdx := -1
 	firstPunctLen := 0
 
 	for _, punc := range punctuations {
-		idx := strings.Index(text, punc)
+		idx := strings.Index(firstParagraph, punc)
 		if idx != -1 && (firstPunctIdx == -1 || idx < firstPunctIdx) {
 			firstPunctIdx = idx
 			firstPunctLen = len(punc)
@@ -1229,22 +4555,64 @@ func (c *Converter) splitDescription(text string) (summary string, description s
 	}
 
 	if firstPunctIdx == -1 {
-		// No punctuation found, use entire text as summary
-		return text, text
+		// No punctuation found, use the opening prose as summary
+		return firstParagraph, text
 	}
 
 	// Split at first punctuation
 	// For " - ", don't include the dash in summary
 	if firstPunctLen == 3 { // " - "
-		summary = strings.TrimSpace(text[:firstPunctIdx])
+		summary = strings.TrimSpace(firstParagraph[:firstPunctIdx])
 	} else {
-		summary = strings.TrimSpace(text[:firstPunctIdx+1])
+		summary = strings.TrimSpace(firstParagraph[:firstPunctIdx+1])
 	}
 	description = strings.TrimSpace(text)
 
 	return summary, description
 }
 
+// markdownBlock matches a line opening a Markdown list item, code fence,
+// heading or block quote
+var markdownBlock = regexp.MustCompile("^\\s*([-*+]\\s|\\d+[.)]\\s|```|~~~|#{1,6}\\s|>)")
+
+// normalizeDescription trims each line, collapses runs of spaces/tabs and
+// collapses consecutive blank lines into a single paragraph break. Markdown
+// stays intact: list items keep their indentation (for nesting) and lines
+// inside code fences are kept as written.
+func normalizeDescription(text string) string {
+	lines := strings.Split(text, "\n")
+	normalized := []string{}
+	blank := false
+	inFence := false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		fence := strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+		if inFence && !fence {
+			normalized = append(normalized, strings.TrimRight(line, " \t"))
+			continue
+		}
+		if fence {
+			inFence = !inFence
+		}
+
+		indent := ""
+		if markdownBlock.MatchString(line) && !fence {
+			indent = line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		}
+		line = indent + strings.Join(strings.Fields(line), " ")
+		if line == "" {
+			blank = len(normalized) > 0
+			continue
+		}
+		if blank {
+			normalized = append(normalized, "")
+			blank = false
+		}
+		normalized = append(normalized, line)
+	}
+	return strings.Join(normalized, "\n")
+}
+
 func isVowel(r rune) bool {
 	return r == 'a' || r == 'e' || r == 'i' || r == 'o' || r == 'u' ||
 		r == 'A' || r == 'E' || r == 'I' || r == 'O' || r == 'U'
@@ -0,0 +1,2333 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// convertSDL converts sdl with config (filled in like New does), failing the
+// test on a conversion error
+func convertSDL(t *testing.T, config Config, sdl string) *OpenAPIDocument {
+	t.Helper()
+	doc, err := New(config).Convert(sdl)
+	if err != nil {
+		t.Fatalf("Convert: %v", err)
+	}
+	return doc
+}
+
+// toJSON renders v for comparisons and failure messages
+func toJSON(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	return string(data)
+}
+
+// operation returns the operation for method on path, failing the test when
+// the document has none
+func operation(t *testing.T, doc *OpenAPIDocument, method, path string) *Operation {
+	t.Helper()
+	item := doc.Paths[path]
+	if item == nil {
+		paths := []string{}
+		for p := range doc.Paths {
+			paths = append(paths, p)
+		}
+		t.Fatalf("no path %s, have %v", path, paths)
+	}
+	var op *Operation
+	switch method {
+	case "get":
+		op = item.Get
+	case "post":
+		op = item.Post
+	case "put":
+		op = item.Put
+	case "patch":
+		op = item.Patch
+	case "delete":
+		op = item.Delete
+	}
+	if op == nil {
+		t.Fatalf("no %s operation on %s", method, path)
+	}
+	return op
+}
+
+func TestArgumentDefaults(t *testing.T) {
+	sdl := `
+enum Status { ACTIVE, ARCHIVED }
+type User { id: ID!, status: Status! }
+type Query { searchUsers(status: Status = ACTIVE, limit: Int = 10): [User!]! }
+`
+	tests := []struct {
+		version string
+		status  string
+	}{
+		{"3.0.0", `{"allOf":[{"$ref":"#/components/schemas/Status"}],"default":"ACTIVE"}`},
+		{"3.1.0", `{"$ref":"#/components/schemas/Status","default":"ACTIVE"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			config := DefaultConfig()
+			config.OpenAPIVersion = tt.version
+			op := operation(t, convertSDL(t, config, sdl), "get", "/searchUsers")
+			if got := toJSON(t, op.Parameters[0].Schema); got != tt.status {
+				t.Errorf("status schema = %s, want %s", got, tt.status)
+			}
+			if got, want := toJSON(t, op.Parameters[1].Schema), `{"type":"integer","format":"int32","default":10}`; got != want {
+				t.Errorf("limit schema = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestTagGroups(t *testing.T) {
+	sdl := `
+type User { id: ID!, name: String! }
+input UserInput { name: String! }
+type Query { users: [User!]!, user(id: ID!): User, me: User }
+type Mutation { createUser(input: UserInput!): User }
+`
+	tests := []struct {
+		name      string
+		tagGroups []TagGroup
+		want      string
+	}{
+		{"default", nil, `[{"name":"Resources","tags":["users"]},{"name":"Operations","tags":["User"]}]`},
+		{"configured", []TagGroup{{Name: "All", Tags: []string{"users", "User"}}}, `[{"name":"All","tags":["users","User"]}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.TagGroups = tt.tagGroups
+			doc := convertSDL(t, config, sdl)
+			if got := toJSON(t, doc.TagGroups); got != tt.want {
+				t.Errorf("x-tagGroups = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnnotateReadWriteOnly(t *testing.T) {
+	sdl := `
+enum Status { ACTIVE, ARCHIVED }
+type User { id: ID!, name: String!, status: Status! }
+input UserInput { name: String!, status: Status! }
+type Query { user(id: ID!): User }
+type Mutation { saveUser(input: UserInput!): User }
+`
+	tests := []struct {
+		version, typeName, property, want string
+	}{
+		{"3.0.0", "User", "name", `{"type":"string","readOnly":true}`},
+		{"3.0.0", "User", "status", `{"allOf":[{"$ref":"#/components/schemas/Status"}],"readOnly":true}`},
+		{"3.0.0", "UserInput", "status", `{"allOf":[{"$ref":"#/components/schemas/Status"}],"writeOnly":true}`},
+		{"3.1.0", "User", "status", `{"$ref":"#/components/schemas/Status","readOnly":true}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version+" "+tt.typeName+"."+tt.property, func(t *testing.T) {
+			config := DefaultConfig()
+			config.OpenAPIVersion = tt.version
+			config.AnnotateReadWriteOnly = true
+			doc := convertSDL(t, config, sdl)
+			if got := toJSON(t, doc.Components.Schemas[tt.typeName].Properties[tt.property]); got != tt.want {
+				t.Errorf("%s.%s = %s, want %s", tt.typeName, tt.property, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthDirectiveRoles(t *testing.T) {
+	config := DefaultConfig()
+	config.AuthDirective = "auth"
+	doc := convertSDL(t, config, `
+directive @auth(roles: [String!]) on FIELD_DEFINITION
+type Report { id: ID!, total: Int! }
+type Query { report(id: ID!): Report @auth(roles: ["admin", "auditor"]) }
+`)
+	op := operation(t, doc, "get", "/report")
+	if got, want := toJSON(t, op.Security), `[{"bearerAuth":[]}]`; got != want {
+		t.Errorf("security = %s, want %s", got, want)
+	}
+	if got, want := toJSON(t, op.Extensions["x-roles"]), `["admin","auditor"]`; got != want {
+		t.Errorf("x-roles = %s, want %s", got, want)
+	}
+}
+
+func TestLicense(t *testing.T) {
+	sdl := `type Query { ping: String }`
+	tests := []struct {
+		name, licenseName, licenseURL, want string
+		warnings                            int
+	}{
+		{"name and url", "MIT", "https://opensource.org/licenses/MIT", `{"name":"MIT","url":"https://opensource.org/licenses/MIT"}`, 0},
+		{"name only", "MIT", "", `{"name":"MIT"}`, 0},
+		{"url only", "", "https://opensource.org/licenses/MIT", `null`, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.LicenseName = tt.licenseName
+			config.LicenseURL = tt.licenseURL
+			c := New(config)
+			doc, err := c.Convert(sdl)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := toJSON(t, doc.Info.License); got != tt.want {
+				t.Errorf("license = %s, want %s", got, tt.want)
+			}
+			if got := len(c.Warnings()); got != tt.warnings {
+				t.Errorf("warnings = %v, want %d", c.Warnings(), tt.warnings)
+			}
+		})
+	}
+}
+
+func TestSplitHybridMutations(t *testing.T) {
+	sdl := `
+type Order { id: ID!, note: String }
+input ApproveInput { note: String }
+type Query { orders: [Order!]! }
+type Mutation { approveOrder(id: ID!, input: ApproveInput!): Order }
+`
+	tests := []struct {
+		split      bool
+		path, body string
+	}{
+		{false, "/approveOrder", `["id","input"]`},
+		{true, "/approveOrder/{id}", `["input"]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			config := DefaultConfig()
+			config.SplitHybridMutations = tt.split
+			op := operation(t, convertSDL(t, config, sdl), "post", tt.path)
+			body := op.RequestBody.Content["application/json"].Schema
+			if got := toJSON(t, body.Required); got != tt.body {
+				t.Errorf("body required = %s, want %s", got, tt.body)
+			}
+		})
+	}
+}
+
+func TestInterfaceAsOneOfTypename(t *testing.T) {
+	config := DefaultConfig()
+	config.InterfaceAsOneOf = true
+	doc := convertSDL(t, config, `
+interface Node { id: ID! }
+type User implements Node { id: ID!, name: String! }
+type Team implements Node { id: ID!, size: Int }
+type Query { node(id: ID!): Node }
+`)
+	op := operation(t, doc, "get", "/node")
+	response := op.Responses["200"].Content["application/json"].Schema
+	if got, want := response.Discriminator.PropertyName, "__typename"; got != want {
+		t.Fatalf("discriminator = %s, want %s", got, want)
+	}
+	for _, name := range []string{"User", "Team"} {
+		own := doc.Components.Schemas[name].AllOf[1]
+		if got, want := toJSON(t, own.Properties["__typename"]), `{"type":"string","enum":["`+name+`"]}`; got != want {
+			t.Errorf("%s.__typename = %s, want %s", name, got, want)
+		}
+		if len(own.Required) == 0 || own.Required[0] != "__typename" {
+			t.Errorf("%s required = %v, want __typename first", name, own.Required)
+		}
+	}
+}
+
+func TestNewFillsDefaults(t *testing.T) {
+	sdl := `
+type User { id: ID!, name: String! }
+input UserInput { name: String! }
+type Query { users: [User!]! }
+type Mutation { createUser(input: UserInput!): User }
+`
+	bare := convertSDL(t, Config{}, sdl)
+	if item := bare.Paths["/users"]; item == nil || item.Post != nil {
+		t.Error("bare Config{} consolidated POST /users, want REST detection off")
+	}
+	operation(t, bare, "post", "/createUser")
+	detecting := convertSDL(t, Config{DetectRESTPatterns: true}, sdl)
+	operation(t, detecting, "post", "/users")
+}
+
+func TestNullableListItems(t *testing.T) {
+	sdl := `
+type User { id: ID!, strict: [String!]!, loose: [String]!, optional: [String!] }
+type Query { user(id: ID!): User }
+`
+	tests := []struct {
+		name            string
+		version         string
+		nullableAsOneOf bool
+		loose           string
+	}{
+		{"3.0", "3.0.0", false, `{"type":"array","items":{"type":"string","nullable":true}}`},
+		{"3.1", "3.1.0", false, `{"type":"array","items":{"type":["string","null"]}}`},
+		{"3.1 oneOf", "3.1.0", true, `{"type":"array","items":{"oneOf":[{"type":"string"},{"type":"null"}]}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.OpenAPIVersion = tt.version
+			config.NullableAsOneOf = tt.nullableAsOneOf
+			user := convertSDL(t, config, sdl).Components.Schemas["User"]
+			if got := toJSON(t, user.Properties["loose"]); got != tt.loose {
+				t.Errorf("loose = %s, want %s", got, tt.loose)
+			}
+			if got, want := toJSON(t, user.Properties["strict"]), `{"type":"array","items":{"type":"string"}}`; got != want {
+				t.Errorf("strict = %s, want %s", got, want)
+			}
+			if got, want := toJSON(t, user.Required), `["id","strict","loose"]`; got != want {
+				t.Errorf("required = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestNullableTypeYAML(t *testing.T) {
+	data, err := yaml.Marshal(&Schema{Type: "string", Format: "email", NullableType: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), "type:\n    - string\n    - \"null\"\nformat: email\n"; got != want {
+		t.Errorf("yaml = %q, want %q", got, want)
+	}
+}
+
+func TestPaginationEnvelope(t *testing.T) {
+	sdl := `
+type User { id: ID!, name: String! }
+input UserInput { name: String! }
+type Query { users: [User!]! }
+type Mutation { createUser(input: UserInput!): User }
+`
+	tests := []struct {
+		name      string
+		named     bool
+		extraSDL  string
+		response  string
+		page      string
+		warnCount int
+	}{
+		{"plain", false, "", `{"$ref":"#/components/schemas/UserPage"}`,
+			`{"type":"object","properties":{"data":{"type":"array","items":{"$ref":"#/components/schemas/User"}},"total":{"type":"integer","description":"Total number of items"}},"required":["data","total"]}`, 0},
+		{"named collection", true, "", `{"$ref":"#/components/schemas/UserPage"}`,
+			`{"type":"object","properties":{"data":{"$ref":"#/components/schemas/UserList"},"total":{"type":"integer","description":"Total number of items"}},"required":["data","total"]}`, 0},
+		{"page type exists", false, "type UserPage { cursor: String }", `{"type":"object","properties":{"data":{"type":"array","items":{"$ref":"#/components/schemas/User"}},"total":{"type":"integer","description":"Total number of items"}},"required":["data","total"]}`,
+			`{"title":"UserPage","type":"object","properties":{"cursor":{"type":"string"}}}`, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.PaginationStyle = "offset"
+			config.NamedCollectionSchemas = tt.named
+			c := New(config)
+			doc, err := c.Convert(sdl + tt.extraSDL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			op := operation(t, doc, "get", "/users")
+			if got := toJSON(t, op.Responses["200"].Content["application/json"].Schema); got != tt.response {
+				t.Errorf("response = %s, want %s", got, tt.response)
+			}
+			if got := toJSON(t, doc.Components.Schemas["UserPage"]); got != tt.page {
+				t.Errorf("UserPage = %s, want %s", got, tt.page)
+			}
+			if tt.named && doc.Components.Schemas["UserList"] == nil {
+				t.Error("UserList component missing")
+			}
+			if got := len(c.Warnings()); got != tt.warnCount {
+				t.Errorf("warnings = %v, want %d", c.Warnings(), tt.warnCount)
+			}
+		})
+	}
+}
+
+func TestCustomActionsInQuery(t *testing.T) {
+	config := DefaultConfig()
+	config.DetectMutationsInQuery = true
+	config.CRUDCustomActions = map[string]string{"archive": "archive"}
+	doc := convertSDL(t, config, `
+type User { id: ID!, name: String! }
+input UserInput { name: String! }
+type Query {
+  users: [User!]!
+  user(id: ID!): User
+  createUser(input: UserInput!): User
+  archiveUser(id: ID!): User
+}
+`)
+	op := operation(t, doc, "post", "/users/{id}/archive")
+	if op.OperationID != "archiveUser" {
+		t.Errorf("operationId = %s, want archiveUser", op.OperationID)
+	}
+	if _, ok := doc.Paths["/archiveUser"]; ok {
+		t.Error("archiveUser is also a GET /archiveUser query")
+	}
+}
+
+func TestClientIDCreateLeavesPatchForUpdate(t *testing.T) {
+	doc := convertSDL(t, Config{DetectRESTPatterns: true}, `
+type User { id: ID! name: String! }
+input UserInput { name: String! }
+type Query { user(id: ID!): User users: [User!]! }
+type Mutation {
+  createUser(id: ID!, name: String!): User!
+  updateUser(id: ID!, input: UserInput!): User!
+}`)
+	if op := operation(t, doc, "put", "/users/{id}"); op.OperationID != "createUser" {
+		t.Errorf("PUT /users/{id} = %s, want createUser", op.OperationID)
+	}
+	if op := operation(t, doc, "patch", "/users/{id}"); op.OperationID != "updateUser" {
+		t.Errorf("PATCH /users/{id} = %s, want updateUser", op.OperationID)
+	}
+	if _, ok := doc.Paths["/users/{id}/updateUser"]; ok {
+		t.Error("updateUser should not be moved off the item path")
+	}
+}
+
+func TestResponseContentTypesExamples(t *testing.T) {
+	sdl := `
+directive @example(value: String) on FIELD_DEFINITION
+type User { id: ID! name: String! @example(value: "Ada") }
+input CreateUserInput { name: String! }
+type Query { users: [User!]! }
+type Mutation { createUser(input: CreateUserInput!): User! }`
+
+	doc := convertSDL(t, Config{DetectRESTPatterns: true, ResponseContentTypes: []string{"application/json", "application/xml"}}, sdl)
+	content := operation(t, doc, "post", "/users").Responses["200"].Content
+	if content["application/json"].Example == nil {
+		t.Error("application/json should keep its example")
+	}
+	if content["application/xml"].Example != nil {
+		t.Error("the JSON example should not be copied under application/xml")
+	}
+
+	for _, types := range [][]string{{"application/xml"}, {"application/xml", "application/vnd.api+json"}} {
+		doc := convertSDL(t, Config{DetectRESTPatterns: true, ResponseContentTypes: types}, sdl)
+		output, err := MarshalPostman(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var collection postmanCollection
+		if err := json.Unmarshal(output, &collection); err != nil {
+			t.Fatal(err)
+		}
+		var body *postmanBody
+		var contentType string
+		for _, folder := range collection.Item {
+			for _, item := range folder.Item {
+				if item.Request.Method == "POST" {
+					body = item.Request.Body
+					for _, header := range item.Request.Header {
+						if header.Key == "Content-Type" {
+							contentType = header.Value
+						}
+					}
+				}
+			}
+		}
+		if body == nil {
+			t.Fatalf("%v: the create request lost its body", types)
+		}
+		want := types[len(types)-1]
+		if contentType != want {
+			t.Errorf("%v: Content-Type = %q, want %q", types, contentType, want)
+		}
+	}
+}
+
+func TestMaxDepth(t *testing.T) {
+	sdl := `
+type User { id: ID!, posts: [Post!]! }
+type Post { id: ID!, comments: [Comment!]! }
+type Comment { id: ID!, body: String! }
+input CreateUserInput { name: String! }
+type Query { users: [User!]!, user(id: ID!): User }
+type Mutation { createUser(input: CreateUserInput!): User! }
+`
+	nested := "/users/{id}/posts/{postId}/comments"
+	tests := []struct {
+		maxDepth int
+		nested   bool
+	}{
+		{0, false},
+		{1, false},
+		{2, true},
+	}
+	for _, tt := range tests {
+		config := DefaultConfig()
+		config.MaxDepth = tt.maxDepth
+		doc := convertSDL(t, config, sdl)
+		operation(t, doc, "get", "/users/{id}/posts")
+		if _, ok := doc.Paths[nested]; ok != tt.nested {
+			t.Errorf("MaxDepth %d: %s present = %v, want %v", tt.maxDepth, nested, ok, tt.nested)
+		}
+	}
+}
+
+func TestOperationTags(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+type User { id: ID!, name: String! }
+type Report { total: Int! }
+input CreateUserInput { name: String! }
+type Query { users: [User!]!, user(id: ID!): User, salesReport: Report }
+type Mutation { createUser(input: CreateUserInput!): User! }
+`)
+	for _, tt := range []struct{ method, path, tag string }{
+		{"get", "/users", "users"},
+		{"post", "/users", "users"},
+		{"get", "/users/{id}", "users"},
+		{"get", "/salesReport", "Report"},
+	} {
+		if got := operation(t, doc, tt.method, tt.path).Tags; len(got) != 1 || got[0] != tt.tag {
+			t.Errorf("%s %s tags = %v, want [%s]", tt.method, tt.path, got, tt.tag)
+		}
+	}
+	if got, want := toJSON(t, doc.Tags), `[{"name":"Report"},{"name":"users"}]`; got != want {
+		t.Errorf("document tags = %s, want %s", got, want)
+	}
+}
+
+func TestNonNullStringsMinLength1(t *testing.T) {
+	config := DefaultConfig()
+	config.NonNullStringsMinLength1 = true
+	doc := convertSDL(t, config, `
+directive @constraint(minLength: Int) on FIELD_DEFINITION
+type User { id: ID!, name: String!, nickname: String, code: String! @constraint(minLength: 3) }
+type Query { user(id: ID!): User }
+`)
+	props := doc.Components.Schemas["User"].Properties
+	for name, want := range map[string]string{
+		"id":       `{"type":"string","minLength":1}`,
+		"name":     `{"type":"string","minLength":1}`,
+		"nickname": `{"type":"string"}`,
+		"code":     `{"type":"string","minLength":3}`,
+	} {
+		if got := toJSON(t, props[name]); got != want {
+			t.Errorf("%s = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestDetectionReport(t *testing.T) {
+	c := New(DefaultConfig())
+	if _, err := c.Convert(`
+type User { id: ID! }
+type Post { id: ID! }
+input CreateUserInput { name: String! }
+type Query { users: [User!]!, posts: [Post!]! }
+type Mutation { createUser(input: CreateUserInput!): User! }
+`); err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]DetectionReport{}
+	for _, report := range c.DetectionReport() {
+		got[report.Resource] = report
+	}
+	if user := got["user"]; user.Status != "consolidated" || user.Path != "/users" || user.Operations["create"] != "createUser" {
+		t.Errorf("user = %+v, want consolidated at /users", user)
+	}
+	if post := got["post"]; post.Status != "filtered" || post.Reason != "missing create mutation (createPost)" {
+		t.Errorf("post = %+v, want filtered for the missing create", post)
+	}
+}
+
+func TestForceRESTResources(t *testing.T) {
+	config := DefaultConfig()
+	config.ForceRESTResources = map[string]RESTResourceFields{
+		"person": {Plural: "people", List: "everyone", Get: "findPerson", Create: "addPerson", Update: "missing"},
+	}
+	c := New(config)
+	doc, err := c.Convert(`
+type Person { id: ID!, name: String! }
+input PersonInput { name: String! }
+type Query { everyone: [Person!]!, findPerson(id: ID!): Person }
+type Mutation { addPerson(input: PersonInput!): Person! }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	operation(t, doc, "get", "/people")
+	operation(t, doc, "post", "/people")
+	operation(t, doc, "get", "/people/{id}")
+	if got, want := toJSON(t, c.Warnings()), `["forced REST resource 'person': update field 'missing' not found"]`; got != want {
+		t.Errorf("warnings = %s, want %s", got, want)
+	}
+}
+
+func TestDisableRESTResources(t *testing.T) {
+	config := DefaultConfig()
+	config.DisableRESTResources = []string{"user"}
+	doc := convertSDL(t, config, `
+type User { id: ID! }
+input CreateUserInput { name: String! }
+type Query { users: [User!]!, user(id: ID!): User }
+type Mutation { createUser(input: CreateUserInput!): User! }
+`)
+	operation(t, doc, "get", "/users")
+	operation(t, doc, "post", "/createUser")
+	if _, ok := doc.Paths["/users/{id}"]; ok {
+		t.Error("a disabled resource should have no item path")
+	}
+}
+
+func TestServers(t *testing.T) {
+	config := DefaultConfig()
+	config.BaseURL = "https://api.example.com"
+	config.Servers = []Server{
+		{URL: "https://api.example.com", Description: "Production"},
+		{URL: "https://staging.example.com", Description: "Staging"},
+	}
+	doc := convertSDL(t, config, `type Query { hello: String }`)
+	want := `[{"url":"https://api.example.com","description":"Production"},{"url":"https://staging.example.com","description":"Staging"}]`
+	if got := toJSON(t, doc.Servers); got != want {
+		t.Errorf("servers = %s, want %s", got, want)
+	}
+}
+
+func TestSharedIDParameter(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+type User { id: ID! }
+input UserInput { name: String! }
+type Query { users: [User!]!, user(id: ID!): User }
+type Mutation { createUser(input: UserInput!): User!, deleteUser(id: ID!): Boolean! }
+`)
+	want := `[{"$ref":"#/components/parameters/IdParam"}]`
+	if got := toJSON(t, doc.Paths["/users/{id}"].Parameters); got != want {
+		t.Errorf("/users/{id} parameters = %s, want %s", got, want)
+	}
+	if param := doc.Components.Parameters["IdParam"]; param == nil || param.Name != "id" || param.In != "path" || !param.Required {
+		t.Errorf("IdParam = %+v, want a required id path parameter", param)
+	}
+}
+
+func TestNormalizeDescription(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"indented block string", "\n    Fetches a user.\n      Returns null when missing.\n", "Fetches a user.\nReturns null when missing."},
+		{"collapsed spaces", "Lists   all\tusers", "Lists all users"},
+		{"paragraph breaks", "First.\n\n\n\nSecond.", "First.\n\nSecond."},
+		{"nested list", "Options:\n- one\n  - nested", "Options:\n- one\n  - nested"},
+		{"code fence", "Example:\n```\n  indented   code\n```", "Example:\n```\n  indented   code\n```"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeDescription(tt.in); got != tt.want {
+				t.Errorf("normalizeDescription(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertToMap(t *testing.T) {
+	c := New(DefaultConfig())
+	if _, err := c.ConvertToMap(); err == nil {
+		t.Error("ConvertToMap before Convert should fail")
+	}
+	if _, err := c.Convert(`type Query { hello: String }`); err != nil {
+		t.Fatal(err)
+	}
+	doc, err := c.ConvertToMap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc["openapi"] != "3.0.0" {
+		t.Errorf("openapi = %v, want 3.0.0", doc["openapi"])
+	}
+	if _, ok := doc["paths"].(map[string]interface{})["/hello"]; !ok {
+		t.Errorf("paths = %v, want /hello", doc["paths"])
+	}
+}
+
+func TestUniqueOperationIDs(t *testing.T) {
+	c := New(DefaultConfig())
+	doc, err := c.Convert(`
+type User { id: ID! }
+input UserInput { name: String! }
+type Query { users: [User!]!, user(id: ID!): User, getUser(email: String!): User }
+type Mutation { createUser(input: UserInput!): User! }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := operation(t, doc, "get", "/getUser").OperationID; got != "getUser" {
+		t.Errorf("GET /getUser operationId = %s, want getUser", got)
+	}
+	if got := operation(t, doc, "get", "/users/{id}").OperationID; got != "getUser2" {
+		t.Errorf("GET /users/{id} operationId = %s, want getUser2", got)
+	}
+	if got, want := toJSON(t, c.Warnings()), `["duplicate operationId 'getUser' on GET /users/{id} renamed to 'getUser2'"]`; got != want {
+		t.Errorf("warnings = %s, want %s", got, want)
+	}
+}
+
+func TestHashCommentSchemaDescription(t *testing.T) {
+	config := DefaultConfig()
+	config.OmitFooter = true
+	doc := convertSDL(t, config, `# Orders API
+#
+# Places and tracks orders.
+type Query { hello: String }
+`)
+	if doc.Info.Title != "Orders API" {
+		t.Errorf("title = %q, want %q", doc.Info.Title, "Orders API")
+	}
+	if doc.Info.Description != "Places and tracks orders." {
+		t.Errorf("description = %q, want %q", doc.Info.Description, "Places and tracks orders.")
+	}
+}
+
+func TestFooterAsExtension(t *testing.T) {
+	config := DefaultConfig()
+	config.FooterAsExtension = true
+	doc := convertSDL(t, config, `"""Orders API"""
+schema { query: Query }
+type Query { hello: String }
+`)
+	if doc.Info.Description != "" {
+		t.Errorf("description = %q, want none", doc.Info.Description)
+	}
+	if doc.GeneratedBy != "Converted from GraphQL (1.0.0)" {
+		t.Errorf("x-generated-by = %q, want the footer", doc.GeneratedBy)
+	}
+}
+
+func TestOneOfInput(t *testing.T) {
+	sdl := `
+directive @%s on INPUT_OBJECT
+type User { id: ID! }
+input UserBy @%s { id: ID, email: String }
+type Query { findUser(by: UserBy!): User }
+`
+	want := `{"title":"UserBy","type":"object","oneOf":[` +
+		`{"type":"object","properties":{"id":{"type":"string"}},"required":["id"]},` +
+		`{"type":"object","properties":{"email":{"type":"string"}},"required":["email"]}]}`
+	for _, directive := range []string{"oneOf", "exactlyOne"} {
+		config := DefaultConfig()
+		if directive != "oneOf" {
+			config.OneOfInputDirective = directive
+		}
+		doc := convertSDL(t, config, fmt.Sprintf(sdl, directive, directive))
+		if got := toJSON(t, doc.Components.Schemas["UserBy"]); got != want {
+			t.Errorf("@%s: UserBy = %s, want %s", directive, got, want)
+		}
+	}
+}
+
+func TestInterfaceAllOf(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+interface Node { id: ID! }
+type User implements Node { id: ID!, name: String! }
+type Query { node(id: ID!): Node }
+`)
+	want := `{"title":"User","allOf":[{"$ref":"#/components/schemas/Node"},` +
+		`{"type":"object","properties":{"name":{"type":"string"}},"required":["name"]}]}`
+	if got := toJSON(t, doc.Components.Schemas["User"]); got != want {
+		t.Errorf("User = %s, want %s", got, want)
+	}
+}
+
+func TestPrefixInServerURL(t *testing.T) {
+	tests := []struct {
+		baseURL, servers, path string
+	}{
+		{"https://api.example.com/", `[{"url":"https://api.example.com/api/v2"}]`, "/hello"},
+		{"", `[{"url":"/api/v2"}]`, "/hello"},
+	}
+	for _, tt := range tests {
+		config := DefaultConfig()
+		config.BaseURL = tt.baseURL
+		config.PathPrefix = "/api/v2"
+		config.PrefixInServerURL = true
+		doc := convertSDL(t, config, `type Query { hello: String }`)
+		if got := toJSON(t, doc.Servers); got != tt.servers {
+			t.Errorf("base URL %q: servers = %s, want %s", tt.baseURL, got, tt.servers)
+		}
+		operation(t, doc, "get", tt.path)
+	}
+}
+
+func TestRequiredByDefault(t *testing.T) {
+	sdl := `
+directive @optional on FIELD_DEFINITION | INPUT_FIELD_DEFINITION
+type User { id: ID!, name: String, nickname: String @optional }
+type Query { user(id: ID!): User }
+`
+	for _, tt := range []struct {
+		requiredByDefault bool
+		want              string
+	}{
+		{false, `["id"]`},
+		{true, `["id","name"]`},
+	} {
+		config := DefaultConfig()
+		config.RequiredByDefault = tt.requiredByDefault
+		doc := convertSDL(t, config, sdl)
+		if got := toJSON(t, doc.Components.Schemas["User"].Required); got != tt.want {
+			t.Errorf("RequiredByDefault %v: required = %s, want %s", tt.requiredByDefault, got, tt.want)
+		}
+	}
+}
+
+func TestNestedLists(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+type Grid { id: ID!, cells: [[Int!]!]!, layers: [[[String]]] }
+type Query { grid(id: ID!): Grid }
+`)
+	props := doc.Components.Schemas["Grid"].Properties
+	for name, want := range map[string]string{
+		"cells":  `{"type":"array","items":{"type":"array","items":{"type":"integer","format":"int32"}}}`,
+		"layers": `{"type":"array","items":{"type":"array","items":{"type":"array","items":{"type":"string","nullable":true},"nullable":true},"nullable":true}}`,
+	} {
+		if got := toJSON(t, props[name]); got != want {
+			t.Errorf("%s = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestRecursiveSubResources(t *testing.T) {
+	config := DefaultConfig()
+	config.MaxDepth = 3
+	doc := convertSDL(t, config, `
+type User { id: ID!, friends: [User!]!, manager: User }
+input CreateUserInput { name: String! }
+type Query { users: [User!]!, user(id: ID!): User }
+type Mutation { createUser(input: CreateUserInput!): User! }
+`)
+	operation(t, doc, "get", "/users/{id}/friends")
+	for path := range doc.Paths {
+		if strings.Count(path, "friends") > 1 {
+			t.Errorf("unexpected recursive path %s", path)
+		}
+	}
+}
+
+func TestSchemaNamePrefixSuffix(t *testing.T) {
+	config := DefaultConfig()
+	config.SchemaNamePrefix = "V2"
+	config.SchemaNameSuffix = "Model"
+	doc := convertSDL(t, config, `
+type User { id: ID!, name: String! }
+type Query { user(id: ID!): User }
+`)
+	schema := doc.Components.Schemas["V2UserModel"]
+	if schema == nil || schema.Title != "User" {
+		t.Fatalf("V2UserModel = %s, want the User schema", toJSON(t, schema))
+	}
+	op := operation(t, doc, "get", "/user")
+	if got := op.Responses["200"].Content["application/json"].Schema.Ref; got != "#/components/schemas/V2UserModel" {
+		t.Errorf("response $ref = %s, want #/components/schemas/V2UserModel", got)
+	}
+}
+
+func TestUnwrapPayloads(t *testing.T) {
+	sdl := `
+type User { id: ID!, name: String! }
+type UserPayload { user: User, errors: [String!] }
+input UserInput { name: String! }
+type Query { users: [User!]!, user(id: ID!): User }
+type Mutation { createUser(input: UserInput!): UserPayload!, updateUser(id: ID!, input: UserInput!): UserPayload! }
+`
+	for _, tt := range []struct {
+		unwrap bool
+		want   string
+	}{
+		{false, "#/components/schemas/UserPayload"},
+		{true, "#/components/schemas/User"},
+	} {
+		config := DefaultConfig()
+		config.UnwrapPayloads = tt.unwrap
+		doc := convertSDL(t, config, sdl)
+		for _, op := range []*Operation{operation(t, doc, "post", "/users"), operation(t, doc, "put", "/users/{id}")} {
+			if got := op.Responses["200"].Content["application/json"].Schema.Ref; got != tt.want {
+				t.Errorf("UnwrapPayloads %v: %s responds with %s, want %s", tt.unwrap, op.OperationID, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestPartialUpdatePatch(t *testing.T) {
+	sdl := `
+type User { id: ID! }
+input CreateUserInput { name: String! }
+input UpdateUserInput { %s }
+type Query { users: [User!]!, user(id: ID!): User }
+type Mutation { createUser(input: CreateUserInput!): User!, updateUser(id: ID!, input: UpdateUserInput!): User! }
+`
+	tests := []struct {
+		name, fields string
+		patch        bool
+		method       string
+	}{
+		{"full update", "name: String!", false, "put"},
+		{"partial update", "name: String", false, "patch"},
+		{"UpdateUsesPatch", "name: String!", true, "patch"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.UpdateUsesPatch = tt.patch
+			doc := convertSDL(t, config, fmt.Sprintf(sdl, tt.fields))
+			if got := operation(t, doc, tt.method, "/users/{id}").OperationID; got != "updateUser" {
+				t.Errorf("%s /users/{id} = %s, want updateUser", tt.method, got)
+			}
+		})
+	}
+}
+
+func TestRestDirective(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+directive @rest(method: String, path: String) on FIELD_DEFINITION
+type User { id: ID! }
+type Mutation { archiveUser(id: ID!, reason: String): User @rest(method: "PUT", path: "/users/{id}/archive") }
+type Query { user(id: ID!): User }
+`)
+	op := operation(t, doc, "put", "/users/{id}/archive")
+	if len(op.Parameters) != 1 || op.Parameters[0].Name != "id" || op.Parameters[0].In != "path" {
+		t.Errorf("parameters = %s, want the id path parameter", toJSON(t, op.Parameters))
+	}
+	want := `{"type":"object","properties":{"reason":{"type":"string"}}}`
+	if got := toJSON(t, op.RequestBody.Content["application/json"].Schema); got != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+	if _, ok := doc.Paths["/archiveUser"]; ok {
+		t.Error("the @rest field should not also get its default path")
+	}
+}
+
+func TestOmitOperationIds(t *testing.T) {
+	config := DefaultConfig()
+	config.OmitOperationIds = true
+	doc := convertSDL(t, config, `
+type User { id: ID! }
+input UserInput { name: String! }
+type Query { users: [User!]!, user(id: ID!): User, search(q: String): [User!]! }
+type Mutation { createUser(input: UserInput!): User! }
+`)
+	for path, item := range doc.Paths {
+		for _, po := range pathOperations(item) {
+			if po.Operation.OperationID != "" {
+				t.Errorf("%s %s has operationId %s", po.Method, path, po.Operation.OperationID)
+			}
+		}
+	}
+}
+
+func TestDeepObjectQueryArguments(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+type User { id: ID! }
+input UserFilter { name: String, age: Int }
+type Query { searchUsers(filter: UserFilter, limit: Int): [User!]! }
+`)
+	op := operation(t, doc, "get", "/searchUsers")
+	want := `[{"name":"filter","in":"query","schema":{"$ref":"#/components/schemas/UserFilter"},"style":"deepObject","explode":true},` +
+		`{"name":"limit","in":"query","schema":{"type":"integer","format":"int32"}}]`
+	if got := toJSON(t, op.Parameters); got != want {
+		t.Errorf("parameters = %s, want %s", got, want)
+	}
+}
+
+func TestPathCase(t *testing.T) {
+	sdl := `
+type User { id: ID!, blogPosts: [Post!]! }
+type Post { id: ID! }
+input CreateUserInput { name: String! }
+type Query { users: [User!]!, user(id: ID!): User, userProfile(userId: ID!): User }
+type Mutation { createUser(input: CreateUserInput!): User! }
+`
+	for _, tt := range []struct {
+		pathCase string
+		paths    []string
+	}{
+		{"", []string{"/userProfile", "/users/{id}/blogPosts"}},
+		{"kebab", []string{"/user-profile", "/users/{id}/blog-posts"}},
+		{"snake", []string{"/user_profile", "/users/{id}/blog_posts"}},
+	} {
+		config := DefaultConfig()
+		config.PathCase = tt.pathCase
+		doc := convertSDL(t, config, sdl)
+		for _, path := range tt.paths {
+			operation(t, doc, "get", path)
+		}
+	}
+}
+
+func TestOperationIDTemplate(t *testing.T) {
+	config := DefaultConfig()
+	config.OperationIDTemplate = "{method}_{resource}"
+	doc := convertSDL(t, config, `
+type User { id: ID! }
+input UserInput { name: String! }
+type Query { users: [User!]!, user(id: ID!): User, health: String }
+type Mutation { createUser(input: UserInput!): User! }
+`)
+	for _, tt := range []struct{ method, path, want string }{
+		{"get", "/users", "get_users"},
+		{"get", "/users/{id}", "get_user"},
+		{"post", "/users", "post_user"},
+		{"get", "/health", "get_health"},
+	} {
+		if got := operation(t, doc, tt.method, tt.path).OperationID; got != tt.want {
+			t.Errorf("%s %s operationId = %s, want %s", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestSecuredOperationResponses(t *testing.T) {
+	config := DefaultConfig()
+	config.AuthDirective = "auth"
+	doc := convertSDL(t, config, `
+directive @auth on FIELD_DEFINITION
+type Query { me: String @auth, health: String }
+`)
+	op := operation(t, doc, "get", "/me")
+	for status, ref := range map[string]string{"401": "#/components/responses/Unauthorized", "403": "#/components/responses/Forbidden"} {
+		if response := op.Responses[status]; response == nil || response.Ref != ref {
+			t.Errorf("%s response = %s, want $ref %s", status, toJSON(t, response), ref)
+		}
+	}
+	if len(operation(t, doc, "get", "/health").Responses) != 1 {
+		t.Error("an unsecured operation should only document its success response")
+	}
+}
+
+func TestMinimalRequestExamples(t *testing.T) {
+	config := DefaultConfig()
+	config.MinimalRequestExamples = true
+	doc := convertSDL(t, config, `
+directive @example(value: String) on INPUT_FIELD_DEFINITION
+type User { id: ID! }
+input CreateUserInput { name: String! @example(value: "Ada"), nickname: String @example(value: "A") }
+type Query { users: [User!]! }
+type Mutation { createUser(input: CreateUserInput!): User! }
+`)
+	body := operation(t, doc, "post", "/users").RequestBody.Content["application/json"]
+	if got, want := toJSON(t, body.Example), `{"input":{"name":"Ada"}}`; got != want {
+		t.Errorf("example = %s, want %s", got, want)
+	}
+}
+
+func TestSchemaNameMap(t *testing.T) {
+	config := DefaultConfig()
+	config.SchemaNameMap = map[string]string{"Schema": "SchemaDefinition"}
+	config.SchemaNamePrefix = "Api"
+	doc := convertSDL(t, config, `
+type Schema { id: ID!, parent: Schema }
+type Query { schema(id: ID!): Schema }
+`)
+	if doc.Components.Schemas["Schema"] != nil || doc.Components.Schemas["ApiSchema"] != nil {
+		t.Error("the mapped type should not keep its GraphQL name")
+	}
+	if doc.Components.Schemas["ApiSchemaDefinition"] == nil {
+		t.Fatal("missing ApiSchemaDefinition")
+	}
+	op := operation(t, doc, "get", "/schema")
+	if got := op.Responses["200"].Content["application/json"].Schema.Ref; got != "#/components/schemas/ApiSchemaDefinition" {
+		t.Errorf("response $ref = %s", got)
+	}
+}
+
+func TestDefaultSecurity(t *testing.T) {
+	config := DefaultConfig()
+	config.DefaultSecurity = SecurityRequirements{{"bearerAuth": {}}}
+	doc := convertSDL(t, config, `
+directive @public on FIELD_DEFINITION
+type Query { me: String, health: String @public }
+`)
+	if got, want := toJSON(t, doc.Security), `[{"bearerAuth":[]}]`; got != want {
+		t.Errorf("security = %s, want %s", got, want)
+	}
+	if doc.Components.SecuritySchemes["bearerAuth"] == nil {
+		t.Error("the default requirement's scheme should be defined")
+	}
+	if got := operation(t, doc, "get", "/health").Security; got == nil || len(got) != 0 {
+		t.Errorf("@public security = %s, want []", toJSON(t, got))
+	}
+	if got := operation(t, doc, "get", "/me"); got.Security != nil || got.Responses["401"] == nil {
+		t.Errorf("/me = %s, want the default security with a 401", toJSON(t, got))
+	}
+}
+
+func TestIncludeExcludeFilters(t *testing.T) {
+	config := DefaultConfig()
+	config.ExcludeTypes = []string{"Internal*"}
+	config.ExcludeOperations = []string{"admin*"}
+	doc := convertSDL(t, config, `
+type User { id: ID! }
+type InternalAudit { id: ID! }
+type Query { user(id: ID!): User, adminUsers: [User!]!, audits: [InternalAudit!]! }
+`)
+	if doc.Components.Schemas["InternalAudit"] != nil {
+		t.Error("InternalAudit should be excluded")
+	}
+	if _, ok := doc.Paths["/adminUsers"]; ok {
+		t.Error("/adminUsers should be excluded")
+	}
+	// References to an excluded type degrade to a generic object
+	audits := operation(t, doc, "get", "/audits").Responses["200"].Content["application/json"].Schema
+	if got, want := toJSON(t, audits), `{"type":"array","items":{"type":"object"}}`; got != want {
+		t.Errorf("/audits responds with %s, want %s", got, want)
+	}
+	operation(t, doc, "get", "/user")
+
+	config = DefaultConfig()
+	config.IncludeOperations = []string{"user"}
+	doc = convertSDL(t, config, `
+type User { id: ID! }
+type Query { user(id: ID!): User, adminUsers: [User!]! }
+`)
+	if len(doc.Paths) != 1 {
+		t.Errorf("paths = %d, want only /user", len(doc.Paths))
+	}
+}
+
+func TestStructuredEnumMetadata(t *testing.T) {
+	config := DefaultConfig()
+	config.StructuredEnumMetadata = true
+	doc := convertSDL(t, config, `
+enum Role {
+  """Full access"""
+  ADMIN
+  VIEWER @deprecated(reason: "use ADMIN")
+}
+type Query { role: Role }
+`)
+	want := `[{"value":"ADMIN","description":"Full access"},{"value":"VIEWER","deprecated":true,"deprecationReason":"use ADMIN"}]`
+	if got := toJSON(t, doc.Components.Schemas["Role"].EnumMetadata); got != want {
+		t.Errorf("x-enum-metadata = %s, want %s", got, want)
+	}
+}
+
+func TestNullableEnumField(t *testing.T) {
+	sdl := `
+enum Status { ACTIVE, ARCHIVED }
+type User { id: ID!, status: Status, role: Status! }
+type Query { user(id: ID!): User }
+`
+	for _, tt := range []struct {
+		version string
+		oneOf   bool
+		status  string
+	}{
+		{"3.0.0", false, `{"nullable":true,"allOf":[{"$ref":"#/components/schemas/Status"}]}`},
+		{"3.1.0", false, `{"$ref":"#/components/schemas/Status"}`},
+		{"3.1.0", true, `{"oneOf":[{"$ref":"#/components/schemas/Status"},{"type":"null"}]}`},
+	} {
+		config := DefaultConfig()
+		config.OpenAPIVersion = tt.version
+		config.NullableAsOneOf = tt.oneOf
+		props := convertSDL(t, config, sdl).Components.Schemas["User"].Properties
+		if got := toJSON(t, props["status"]); got != tt.status {
+			t.Errorf("%s: status = %s, want %s", tt.version, got, tt.status)
+		}
+		if got := toJSON(t, props["role"]); got != `{"$ref":"#/components/schemas/Status"}` {
+			t.Errorf("%s: role = %s, want a plain $ref", tt.version, got)
+		}
+	}
+}
+
+func TestTreatIDAsInteger(t *testing.T) {
+	config := DefaultConfig()
+	config.TreatIDAsInteger = true
+	doc := convertSDL(t, config, `
+type User { id: ID!, managerId: ID }
+input UserInput { name: String! }
+type Query { users: [User!]!, user(id: ID!): User }
+type Mutation { createUser(input: UserInput!): User! }
+`)
+	want := `{"type":"integer","format":"int64"}`
+	if got := toJSON(t, doc.Components.Schemas["User"].Properties["id"]); got != want {
+		t.Errorf("User.id = %s, want %s", got, want)
+	}
+	param := doc.Components.Parameters["IdParam"]
+	if got := toJSON(t, param.Schema); got != want || param.Example != 1 {
+		t.Errorf("IdParam = %s, want %s with an integer example", toJSON(t, param), want)
+	}
+}
+
+func TestEnumValueDescriptions(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+"""Access level"""
+enum Role {
+  """Full access"""
+  ADMIN
+  VIEWER @deprecated(reason: "use ADMIN")
+  GUEST
+}
+type Query { role: Role }
+`)
+	want := "Access level\n\n- ADMIN: Full access\n- VIEWER (DEPRECATED: use ADMIN)\n- GUEST"
+	if got := doc.Components.Schemas["Role"].Description; got != want {
+		t.Errorf("description = %q, want %q", got, want)
+	}
+}
+
+func TestParameterlessQuery(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `type Query { ping: String }`)
+	op := operation(t, doc, "get", "/ping")
+	if len(op.Parameters) != 0 || op.RequestBody != nil {
+		t.Errorf("GET /ping = %s, want no parameters or body", toJSON(t, op))
+	}
+}
+
+func TestEmitContentTypeForEmptyResponses(t *testing.T) {
+	sdl := `
+type User { id: ID! }
+input UserInput { name: String! }
+type Query { users: [User!]!, user(id: ID!): User }
+type Mutation { createUser(input: UserInput!): User!, deleteUser(id: ID!): Boolean! }
+`
+	for _, tt := range []struct {
+		emit bool
+		want string
+	}{
+		{false, `{"description":"Deleted"}`},
+		{true, `{"description":"Deleted","content":{}}`},
+	} {
+		config := DefaultConfig()
+		config.EmitContentTypeForEmptyResponses = tt.emit
+		op := operation(t, convertSDL(t, config, sdl), "delete", "/users/{id}")
+		if got := toJSON(t, op.Responses["204"]); got != tt.want {
+			t.Errorf("emit %v: 204 = %s, want %s", tt.emit, got, tt.want)
+		}
+	}
+}
+
+func TestDeprecatedParameters(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+type Query { search(q: String, limit: Int @deprecated(reason: "use first")): [String!]! }
+`)
+	want := `{"name":"limit","in":"query","description":"DEPRECATED: use first","deprecated":true,"schema":{"type":"integer","format":"int32"}}`
+	if got := toJSON(t, operation(t, doc, "get", "/search").Parameters[1]); got != want {
+		t.Errorf("limit = %s, want %s", got, want)
+	}
+}
+
+func TestExampleDirective(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+directive @example(value: String) on FIELD_DEFINITION | ARGUMENT_DEFINITION
+type User { id: ID!, name: String! @example(value: "Ada") }
+type Query { user(id: ID!): User, search(q: String @example(value: "ada")): [User!]! }
+`)
+	if got := doc.Components.Schemas["User"].Properties["name"].Example; got != "Ada" {
+		t.Errorf("User.name example = %v, want Ada", got)
+	}
+	if got := operation(t, doc, "get", "/search").Parameters[0].Example; got != "ada" {
+		t.Errorf("q example = %v, want ada", got)
+	}
+	response := operation(t, doc, "get", "/user").Responses["200"].Content["application/json"]
+	if got, want := toJSON(t, response.Example), `{"name":"Ada"}`; got != want {
+		t.Errorf("response example = %s, want %s", got, want)
+	}
+}
+
+func TestCustomActions(t *testing.T) {
+	config := DefaultConfig()
+	config.CRUDCustomActions = map[string]string{"archive": "archive", "restore": "restore"}
+	doc := convertSDL(t, config, `
+type User { id: ID! }
+input UserInput { name: String! }
+type Query { users: [User!]!, user(id: ID!): User }
+type Mutation { createUser(input: UserInput!): User!, archiveUser(id: ID!): User!, restoreUser(id: ID!): User!, archiveAll: Boolean }
+`)
+	for _, path := range []string{"/users/{id}/archive", "/users/{id}/restore"} {
+		op := operation(t, doc, "post", path)
+		if got := op.Responses["200"].Content["application/json"].Schema.Ref; got != "#/components/schemas/User" {
+			t.Errorf("%s responds with %s, want the User", path, got)
+		}
+	}
+	operation(t, doc, "post", "/archiveAll")
+}
+
+func TestFieldFormats(t *testing.T) {
+	config := DefaultConfig()
+	config.FieldFormats = map[string]string{"User.email": "email", "User.avatar": "uri"}
+	doc := convertSDL(t, config, `
+type User { id: ID!, email: String!, avatar: String, name: String }
+type Query { user(id: ID!): User }
+`)
+	props := doc.Components.Schemas["User"].Properties
+	for name, want := range map[string]string{"email": "email", "avatar": "uri", "name": ""} {
+		if got := props[name].Format; got != want {
+			t.Errorf("%s format = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestGetByIDArgumentNames(t *testing.T) {
+	sdl := `
+type User { id: ID!, uuid: String! }
+input UserInput { name: String! }
+type Query { users: [User!]!, user(%s): User }
+type Mutation { createUser(input: UserInput!): User! }
+`
+	for _, tt := range []struct {
+		args, path string
+		idNames    []string
+	}{
+		{"userId: ID!", "/users/{userId}", nil},
+		{"uuid: String!", "/users/{uuid}", []string{"uuid"}},
+		{"id: ID", "/user", nil},
+	} {
+		config := DefaultConfig()
+		config.ResourceIDFieldNames = tt.idNames
+		doc := convertSDL(t, config, fmt.Sprintf(sdl, tt.args))
+		operation(t, doc, "get", tt.path)
+	}
+}
+
+func TestCompositeKeyPath(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+type Membership { orgId: ID!, userId: ID!, role: String }
+type Query { membership(orgId: ID!, userId: ID!, expand: Boolean): Membership }
+`)
+	op := operation(t, doc, "get", "/memberships/{orgId}/{userId}")
+	got := []string{}
+	for _, param := range op.Parameters {
+		got = append(got, param.In+":"+param.Name)
+	}
+	if want := `["path:orgId","path:userId","query:expand"]`; toJSON(t, got) != want {
+		t.Errorf("parameters = %s, want %s", toJSON(t, got), want)
+	}
+}
+
+func TestSubscriptionEventSchema(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+type Task { id: ID! }
+type Query { task(id: ID!): Task }
+type Subscription { taskUpdated(id: ID!): Task! }
+`)
+	op := operation(t, doc, "get", "/taskUpdated/{id}")
+	schema := op.Responses["200"].Content["text/event-stream"].Schema
+	if got, want := toJSON(t, schema.Properties["data"].AllOf), `[{"$ref":"#/components/schemas/Task"}]`; got != want {
+		t.Errorf("data = %s, want %s", got, want)
+	}
+	if got, want := toJSON(t, schema.Properties["event"].Enum), `["taskUpdated"]`; got != want {
+		t.Errorf("event = %s, want %s", got, want)
+	}
+}
+
+func TestResponseDirective(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+directive @response(status: Int!, description: String) repeatable on FIELD_DEFINITION
+type User { id: ID! }
+type Query { user(id: ID!): User }
+type Mutation {
+  register(name: String!): User @response(status: 201, description: "Created") @response(status: 409, description: "Name already taken")
+}
+`)
+	responses := operation(t, doc, "post", "/register").Responses
+	if responses["200"] != nil {
+		t.Error("@response should replace the default 200")
+	}
+	if created := responses["201"]; created == nil || created.Description != "Created" || created.Content["application/json"] == nil {
+		t.Errorf("201 = %s, want Created with the body", toJSON(t, created))
+	}
+	if conflict := responses["409"]; conflict == nil || conflict.Description != "Name already taken" || conflict.Content != nil {
+		t.Errorf("409 = %s, want a bodiless Name already taken", toJSON(t, conflict))
+	}
+}
+
+func TestInternalDirective(t *testing.T) {
+	for _, name := range []string{"", "hidden"} {
+		directive := name
+		if directive == "" {
+			directive = "internal"
+		}
+		config := DefaultConfig()
+		config.InternalDirective = name
+		doc := convertSDL(t, config, fmt.Sprintf(`
+directive @%s on FIELD_DEFINITION
+type Debug { id: ID! }
+type Query { debug: Debug @%s, health: String }
+`, directive, directive))
+		if _, ok := doc.Paths["/debug"]; ok {
+			t.Errorf("@%s field should have no endpoint", directive)
+		}
+		if doc.Components.Schemas["Debug"] == nil {
+			t.Errorf("@%s: Debug should still be converted", directive)
+		}
+		operation(t, doc, "get", "/health")
+	}
+}
+
+func TestExternalDocs(t *testing.T) {
+	config := DefaultConfig()
+	config.ExternalDocsURL = "https://docs.example.com"
+	config.ExternalDocsDescription = "Guides"
+	doc := convertSDL(t, config, `
+directive @externalDocs(url: String!, description: String) on FIELD_DEFINITION
+type Query { report: String @externalDocs(url: "https://docs.example.com/report", description: "Report docs") }
+`)
+	if got, want := toJSON(t, doc.ExternalDocs), `{"description":"Guides","url":"https://docs.example.com"}`; got != want {
+		t.Errorf("document externalDocs = %s, want %s", got, want)
+	}
+	op := operation(t, doc, "get", "/report")
+	if got, want := toJSON(t, op.ExternalDocs), `{"description":"Report docs","url":"https://docs.example.com/report"}`; got != want {
+		t.Errorf("operation externalDocs = %s, want %s", got, want)
+	}
+}
+
+func TestDuplicatePaths(t *testing.T) {
+	c := New(DefaultConfig())
+	doc, err := c.Convert(`
+directive @rest(method: String, path: String) on FIELD_DEFINITION
+type Query {
+  a: String @rest(method: "GET", path: "/things")
+  b: String @rest(method: "GET", path: "/things")
+  d: String @rest(method: "GET", path: "/things")
+}
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, want := range map[string]string{"/things": "a", "/things/b": "b", "/things/d": "d"} {
+		if got := operation(t, doc, "get", path).OperationID; got != want {
+			t.Errorf("GET %s = %s, want %s", path, got, want)
+		}
+	}
+	if len(c.Warnings()) != 2 {
+		t.Errorf("warnings = %s, want one per moved operation", toJSON(t, c.Warnings()))
+	}
+}
+
+func TestIntFormat(t *testing.T) {
+	sdl := `type Counter { id: ID!, count: Int! }
+type Query { counter(id: ID!): Counter }`
+	for _, tt := range []struct{ format, want string }{
+		{"", `{"type":"integer","format":"int32"}`},
+		{"int32", `{"type":"integer","format":"int32"}`},
+		{"int64", `{"type":"integer","format":"int64"}`},
+	} {
+		config := DefaultConfig()
+		config.IntFormat = tt.format
+		doc := convertSDL(t, config, sdl)
+		if got := toJSON(t, doc.Components.Schemas["Counter"].Properties["count"]); got != tt.want {
+			t.Errorf("IntFormat %q: count = %s, want %s", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestStrictObjects(t *testing.T) {
+	config := DefaultConfig()
+	config.StrictObjects = true
+	doc := convertSDL(t, config, `
+interface Node { id: ID! }
+type User implements Node { id: ID!, name: String! }
+input UserInput { name: String! }
+type Query { users: [User!]!, node(id: ID!): Node }
+type Mutation { createUser(input: UserInput!): User! }
+`)
+	for name, strict := range map[string]bool{"UserInput": true, "Node": false} {
+		schema := doc.Components.Schemas[name]
+		if got := schema.AdditionalProperties == false; got != strict {
+			t.Errorf("%s additionalProperties: false = %v, want %v", name, got, strict)
+		}
+	}
+	if user := doc.Components.Schemas["User"]; user.AdditionalProperties != nil {
+		t.Errorf("User, composed with allOf, should stay permissive: %s", toJSON(t, user))
+	}
+	body := operation(t, doc, "post", "/users").RequestBody.Content["application/json"].Schema
+	if body.AdditionalProperties != false {
+		t.Errorf("request body = %s, want additionalProperties: false", toJSON(t, body))
+	}
+}
+
+func TestSpecifiedByFormats(t *testing.T) {
+	tests := []struct{ url, want string }{
+		{"https://tools.ietf.org/html/rfc4122", "uuid"},
+		{"https://datatracker.ietf.org/doc/html/rfc3339#section-5.6", "date"},
+		{"https://scalars.graphql.org/andimarek/date-time", "date-time"},
+		{"https://scalars.graphql.org/andimarek/local-date", "date"},
+		{"https://www.rfc-editor.org/rfc/rfc5322", "email"},
+		{"https://www.rfc-editor.org/rfc/rfc3986", "uri"},
+		{"https://example.com/BigInt", "int64"},
+		{"https://example.com/unknown", ""},
+	}
+	config := DefaultConfig()
+	config.SpecifiedByFormats = map[string]string{"rfc3339#section-5.6": "date"}
+	c := New(config)
+	for _, tt := range tests {
+		if got := c.specifiedByFormat(tt.url); got != tt.want {
+			t.Errorf("specifiedByFormat(%s) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestReferenceFieldRenames(t *testing.T) {
+	sdl := `
+type User { id: ID! }
+type Post { id: ID!, author: User!, editor: User }
+type Query { post(id: ID!): Post, user(id: ID!): User }
+`
+	c := New(DefaultConfig())
+	for i := 0; i < 2; i++ {
+		doc, err := c.Convert(sdl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		post := doc.Components.Schemas["Post"]
+		if got, want := toJSON(t, post.Required), `["id","authorId"]`; got != want {
+			t.Errorf("conversion %d: required = %s, want %s", i+1, got, want)
+		}
+		if post.Properties["authorId"] == nil || post.Properties["editorId"] == nil || post.Properties["author"] != nil {
+			t.Errorf("conversion %d: properties = %s", i+1, toJSON(t, post.Properties))
+		}
+	}
+}
+
+func TestHeadAndOptions(t *testing.T) {
+	config := DefaultConfig()
+	config.GenerateHead = true
+	config.GenerateOptions = true
+	doc := convertSDL(t, config, `
+type User { id: ID! }
+input UserInput { name: String! }
+type Query { users: [User!]!, user(id: ID!): User }
+type Mutation { createUser(input: UserInput!): User!, deleteUser(id: ID!): Boolean! }
+`)
+	for path, allow := range map[string]string{"/users": "GET, POST, HEAD, OPTIONS", "/users/{id}": "GET, DELETE, HEAD, OPTIONS"} {
+		item := doc.Paths[path]
+		if item.Head == nil || item.Head.Responses["200"].Content != nil {
+			t.Errorf("%s HEAD = %s, want a bodiless 200", path, toJSON(t, item.Head))
+		}
+		if item.Options == nil || item.Options.Responses["204"].Headers["Allow"].Description != allow {
+			t.Errorf("%s OPTIONS = %s, want Allow: %s", path, toJSON(t, item.Options), allow)
+		}
+	}
+}
+
+func TestHTTPMethodDirective(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+directive @httpMethod(method: String!) on FIELD_DEFINITION
+type Query { generateReport(from: String!, to: String): String @httpMethod(method: "POST") }
+`)
+	if item := doc.Paths["/generateReport"]; item.Get != nil {
+		t.Error("the query should not also be a GET")
+	}
+	body := operation(t, doc, "post", "/generateReport").RequestBody.Content["application/json"].Schema
+	want := `{"type":"object","properties":{"from":{"type":"string"},"to":{"type":"string"}},"required":["from"]}`
+	if got := toJSON(t, body); got != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+}
+
+func TestResourceDirective(t *testing.T) {
+	config := DefaultConfig()
+	config.CustomPlurals = map[string]string{"person": "persons"}
+	doc := convertSDL(t, config, `
+directive @resource(name: String, path: String) on OBJECT
+type Person @resource(name: "people") { id: ID! }
+type Datum @resource(name: "data", path: "/v1/data") { id: ID! }
+input PersonInput { name: String! }
+input DatumInput { value: String! }
+type Query { people: [Person!]!, person(id: ID!): Person, data: [Datum!]!, datum(id: ID!): Datum }
+type Mutation { createPerson(input: PersonInput!): Person!, createDatum(input: DatumInput!): Datum! }
+`)
+	for _, path := range []string{"/people", "/people/{id}", "/v1/data", "/v1/data/{id}"} {
+		if doc.Paths[path] == nil {
+			t.Errorf("missing %s", path)
+		}
+	}
+	if doc.Paths["/persons"] != nil {
+		t.Error("@resource should win over the pluralization config")
+	}
+}
+
+func TestInputSchemaNameCollision(t *testing.T) {
+	config := DefaultConfig()
+	config.SchemaNameMap = map[string]string{"UserData": "User"}
+	c := New(config)
+	doc, err := c.Convert(`
+type User { id: ID!, name: String! }
+input UserData { name: String! }
+type Query { user(id: ID!): User }
+type Mutation { saveUser(data: UserData!): User! }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := doc.Components.Schemas["User"].Title; got != "User" {
+		t.Errorf("User title = %q, want the output type", got)
+	}
+	if got := doc.Components.Schemas["UserInput"].Title; got != "UserData" {
+		t.Errorf("UserInput title = %q, want the input type", got)
+	}
+	body := operation(t, doc, "post", "/saveUser").RequestBody.Content["application/json"].Schema
+	if got := body.Properties["data"].Ref; got != "#/components/schemas/UserInput" {
+		t.Errorf("data refers to %s, want the renamed input", got)
+	}
+	if len(c.Warnings()) != 1 {
+		t.Errorf("warnings = %q, want the rename reported", c.Warnings())
+	}
+}
+
+func TestSchemaTitles(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+enum Role { ADMIN, GUEST }
+interface Node { id: ID! }
+type User implements Node { id: ID!, role: Role! }
+type Bot implements Node { id: ID! }
+union Actor = User | Bot
+input UserInput { role: Role! }
+type Query { node(id: ID!): Node, actors: [Actor!]! }
+type Mutation { createUser(input: UserInput!): User! }
+`)
+	for _, name := range []string{"Role", "Node", "User", "Bot", "Actor", "UserInput"} {
+		if got := doc.Components.Schemas[name].Title; got != name {
+			t.Errorf("%s title = %q", name, got)
+		}
+	}
+}
+
+func TestSubscriptionWebhooks(t *testing.T) {
+	sdl := `
+type Task { id: ID! }
+type Query { task(id: ID!): Task }
+type Subscription { onTaskUpdated(id: ID!): Task! }
+`
+	for version, webhook := range map[string]bool{"3.0.0": false, "3.1.0": true} {
+		config := DefaultConfig()
+		config.OpenAPIVersion = version
+		doc := convertSDL(t, config, sdl)
+		if got := doc.Webhooks["onTaskUpdated"] != nil; got != webhook {
+			t.Errorf("%s: webhook listed = %v, want %v", version, got, webhook)
+			continue
+		}
+		operation(t, doc, "get", "/onTaskUpdated/{id}") // the SSE endpoint stays either way
+		if webhook {
+			body := doc.Webhooks["onTaskUpdated"].Post.RequestBody.Content["application/json"].Schema
+			if body.Ref != "#/components/schemas/Task" {
+				t.Errorf("webhook delivers %s, want the Task", toJSON(t, body))
+			}
+		}
+	}
+}
+
+func TestWebSocketSubscriptions(t *testing.T) {
+	config := DefaultConfig()
+	config.SubscriptionTransport = "websocket"
+	doc := convertSDL(t, config, `
+type Task { id: ID! }
+type Query { task(id: ID!): Task }
+type Subscription { onTaskUpdated: Task! }
+`)
+	op := operation(t, doc, "get", "/onTaskUpdated")
+	if _, ok := op.Responses["200"]; ok || len(op.Responses) != 1 {
+		t.Fatalf("responses = %s, want only the 101 upgrade", toJSON(t, op.Responses))
+	}
+	upgrade := op.Responses["101"]
+	if !strings.HasPrefix(upgrade.Description, "Switching Protocols") || upgrade.Headers["Upgrade"] == nil {
+		t.Errorf("101 = %s", toJSON(t, upgrade))
+	}
+	if !strings.Contains(op.Description, "WebSocket") || strings.Contains(op.Description, "Server-Sent Events") {
+		t.Errorf("description = %q", op.Description)
+	}
+}
+
+func TestDocumentCORS(t *testing.T) {
+	config := DefaultConfig()
+	config.DocumentCORS = true
+	doc := convertSDL(t, config, `
+type User { id: ID! }
+input UserInput { name: String! }
+type Query { users: [User!]!, user(id: ID!): User }
+type Mutation { createUser(input: UserInput!): User! }
+`)
+	preflight := doc.Paths["/users"].Options
+	if preflight == nil {
+		t.Fatal("no preflight on /users")
+	}
+	if preflight.Description != "Allowed methods: GET, POST, OPTIONS" {
+		t.Errorf("description = %q", preflight.Description)
+	}
+	response := preflight.Responses["204"]
+	for _, header := range []string{"Access-Control-Allow-Origin", "Access-Control-Allow-Methods", "Access-Control-Allow-Headers", "Access-Control-Max-Age"} {
+		if response == nil || response.Headers[header] == nil {
+			t.Errorf("204 lacks %s", header)
+		}
+	}
+	if doc.Paths["/users/{id}"].Options == nil {
+		t.Error("no preflight on /users/{id}")
+	}
+}
+
+func TestPaginationTotalCountHeader(t *testing.T) {
+	config := DefaultConfig()
+	config.PaginationStyle = "offset"
+	doc := convertSDL(t, config, `
+type User { id: ID! }
+input UserInput { name: String! }
+type Query { users: [User!]! }
+type Mutation { createUser(input: UserInput!): User }
+`)
+	header := operation(t, doc, "get", "/users").Responses["200"].Headers["X-Total-Count"]
+	if header == nil || header.Schema.Type != "integer" {
+		t.Errorf("X-Total-Count = %s, want an integer header", toJSON(t, header))
+	}
+	if headers := operation(t, doc, "post", "/users").Responses["200"].Headers; headers != nil {
+		t.Errorf("create declares headers %s", toJSON(t, headers))
+	}
+}
+
+func TestIDPathParameterExample(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+directive @example(value: String) on ARGUMENT_DEFINITION
+type Task { id: ID! }
+type Query { task(id: ID!): Task }
+type Subscription { onTask(id: ID!): Task!, onNamedTask(id: ID! @example(value: "task-1")): Task! }
+`)
+	for path, want := range map[string]interface{}{"/onTask/{id}": "3fa85f64-5717-4562-b3fc-2c963f66afa6", "/onNamedTask/{id}": "task-1"} {
+		if got := operation(t, doc, "get", path).Parameters[0].Example; got != want {
+			t.Errorf("%s id example = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestDirectiveExtensions(t *testing.T) {
+	config := DefaultConfig()
+	config.DirectiveExtensions = map[string]string{"rateLimit": "x-rate-limit"}
+	doc := convertSDL(t, config, `
+directive @rateLimit(max: Int, per: String) on FIELD_DEFINITION
+type User { id: ID!, email: String @rateLimit(max: 3) }
+input UserInput { name: String! }
+type Query { users: [User!]! @rateLimit(max: 100, per: "minute"), user(id: ID!): User }
+type Mutation { createUser(input: UserInput!): User! }
+`)
+	if got, want := toJSON(t, operation(t, doc, "get", "/users").Extensions["x-rate-limit"]), `{"max":100,"per":"minute"}`; got != want {
+		t.Errorf("list x-rate-limit = %s, want %s", got, want)
+	}
+	if got := operation(t, doc, "get", "/users/{id}").Extensions; got["x-rate-limit"] != nil {
+		t.Errorf("get operation extensions = %v, want none", got)
+	}
+	if got, want := toJSON(t, doc.Components.Schemas["User"].Properties["email"].Extensions["x-rate-limit"]), `{"max":3}`; got != want {
+		t.Errorf("email x-rate-limit = %s, want %s", got, want)
+	}
+}
+
+func TestEmbedObjectReferences(t *testing.T) {
+	sdl := `
+type User { id: ID!, name: String!, manager: User }
+type Post { id: ID!, author: User! }
+type Query { post(id: ID!): Post }
+`
+	tests := []struct {
+		depth int
+		want  string
+	}{
+		{0, `{"type":"object","properties":{"id":{"type":"string"},"managerId":{"type":"string","description":"Reference to User.id - use GET /users/{managerId}"},"name":{"type":"string"}},"required":["id","name"]}`},
+		{2, `{"type":"object","properties":{"id":{"type":"string"},"manager":{"type":"object","properties":{"id":{"type":"string"},"managerId":{"type":"string","description":"Reference to User.id - use GET /users/{managerId}"},"name":{"type":"string"}},"required":["id","name"]},"name":{"type":"string"}},"required":["id","name"]}`},
+	}
+	for _, tt := range tests {
+		config := DefaultConfig()
+		config.EmbedObjectReferences = true
+		config.MaxEmbedDepth = tt.depth
+		doc := convertSDL(t, config, sdl)
+		post := doc.Components.Schemas["Post"]
+		if got := toJSON(t, post.Properties["author"]); got != tt.want {
+			t.Errorf("MaxEmbedDepth %d: author = %s, want %s", tt.depth, got, tt.want)
+		}
+	}
+}
+
+func TestResourceIDFieldNames(t *testing.T) {
+	config := DefaultConfig()
+	config.ResourceIDFieldNames = []string{"uuid"}
+	doc := convertSDL(t, config, `
+type User { uuid: ID! }
+input UserInput { name: String! }
+type Query { users: [User!]!, user(uuid: ID!): User }
+type Mutation { createUser(input: UserInput!): User!, deleteUser(uuid: ID!): Boolean! }
+`)
+	op := operation(t, doc, "get", "/users/{uuid}")
+	if op.OperationID != "getUser" {
+		t.Errorf("operationId = %s, want getUser", op.OperationID)
+	}
+	operation(t, doc, "delete", "/users/{uuid}")
+}
+
+func TestSharedAuthResponses(t *testing.T) {
+	config := DefaultConfig()
+	config.AuthDirective = "auth"
+	doc := convertSDL(t, config, `
+directive @auth on FIELD_DEFINITION
+type User { id: ID! }
+type Query { me: User @auth, admin: User @auth, hello: String }
+`)
+	for _, path := range []string{"/me", "/admin"} {
+		responses := operation(t, doc, "get", path).Responses
+		if responses["401"].Ref != "#/components/responses/Unauthorized" || responses["403"].Ref != "#/components/responses/Forbidden" {
+			t.Errorf("%s responses = %s", path, toJSON(t, responses))
+		}
+	}
+	if _, ok := operation(t, doc, "get", "/hello").Responses["401"]; ok {
+		t.Error("the public operation should not document 401")
+	}
+	unauthorized := doc.Components.Responses["Unauthorized"]
+	if unauthorized == nil || unauthorized.Content["application/json"].Schema.Ref != "#/components/schemas/Error" {
+		t.Errorf("Unauthorized = %s", toJSON(t, unauthorized))
+	}
+}
+
+func TestMapScalars(t *testing.T) {
+	config := DefaultConfig()
+	config.MapScalars = map[string]string{"JSON": "any", "StringMap": "string", "Counts": "integer"}
+	doc := convertSDL(t, config, `
+scalar JSON
+scalar StringMap
+scalar Counts
+type Item { meta: JSON, labels: StringMap, counts: Counts }
+type Query { item: Item }
+`)
+	properties := doc.Components.Schemas["Item"].Properties
+	for name, want := range map[string]string{
+		"meta":   `{"type":"object","additionalProperties":true}`,
+		"labels": `{"type":"object","additionalProperties":{"type":"string"}}`,
+		"counts": `{"type":"object","additionalProperties":{"type":"integer"}}`,
+	} {
+		if got := toJSON(t, properties[name]); got != want {
+			t.Errorf("%s = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestDeleteInputID(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+type User { id: ID! }
+input UserInput { name: String! }
+input DeleteUserInput { id: ID! }
+type Query { users: [User!]!, user(id: ID!): User }
+type Mutation { createUser(input: UserInput!): User!, deleteUser(input: DeleteUserInput!): Boolean! }
+`)
+	op := operation(t, doc, "delete", "/users/{id}")
+	if op.RequestBody != nil {
+		t.Errorf("request body = %s, want the id in the path only", toJSON(t, op.RequestBody))
+	}
+}
+
+func TestPathBuilder(t *testing.T) {
+	config := DefaultConfig()
+	kinds := map[string]string{}
+	config.PathBuilder = func(ctx PathContext) string {
+		kinds[ctx.Path] = ctx.Kind
+		if ctx.Kind == "collection" {
+			return "/tenants/{tenantId}" + ctx.Path
+		}
+		return ctx.Path
+	}
+	doc := convertSDL(t, config, `
+type User { id: ID! }
+input UserInput { name: String! }
+type Query { users: [User!]!, user(id: ID!): User, hello: String }
+type Mutation { createUser(input: UserInput!): User! }
+`)
+	for _, path := range []string{"/tenants/{tenantId}/users", "/tenants/{tenantId}/users/{id}", "/hello"} {
+		if doc.Paths[path] == nil {
+			t.Errorf("missing %s", path)
+		}
+	}
+	if kinds["/users"] != "collection" || kinds["/tenants/{tenantId}/users/{id}"] != "item" || kinds["/hello"] != "query" {
+		t.Errorf("kinds = %v", kinds)
+	}
+}
+
+func TestSplitDescriptionMarkdown(t *testing.T) {
+	tests := []struct{ in, summary string }{
+		{"Lists users\n- active only\n- sorted by name", "Lists users"},
+		{"Runs a query:\n```\nquery { me }\n```", "Runs a query:"},
+		{"- one\n- two", ""},
+		{"Fetches a user. Returns null when missing.", "Fetches a user."},
+	}
+	c := New(DefaultConfig())
+	for _, tt := range tests {
+		summary, description := c.splitDescription(tt.in)
+		if summary != tt.summary || description != tt.in {
+			t.Errorf("splitDescription(%q) = %q, %q; want %q and the text", tt.in, summary, description, tt.summary)
+		}
+	}
+}
+
+func TestOmitFooter(t *testing.T) {
+	sdl := `# Orders API
+#
+# Places and tracks orders.
+type Query { hello: String }
+`
+	for omit, want := range map[bool]string{false: "Places and tracks orders.\n\n---\n\nConverted from GraphQL (1.0.0)", true: "Places and tracks orders."} {
+		config := DefaultConfig()
+		config.OmitFooter = omit
+		if got := convertSDL(t, config, sdl).Info.Description; got != want {
+			t.Errorf("OmitFooter %v: description = %q, want %q", omit, got, want)
+		}
+	}
+}
+
+func TestDefaultDirective(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+directive @default(value: String) on FIELD_DEFINITION
+type Settings { enabled: Boolean @default(value: true), limit: Int @default(value: 10), theme: String @default(value: "dark") }
+type Query { settings: Settings }
+`)
+	properties := doc.Components.Schemas["Settings"].Properties
+	for name, want := range map[string]string{"enabled": "true", "limit": "10", "theme": `"dark"`} {
+		if got := toJSON(t, properties[name].Default); got != want {
+			t.Errorf("%s default = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestInterfaceRequiredFields(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+interface Node { id: ID!, label: String, tags: [String!]! }
+type User implements Node { id: ID!, label: String, tags: [String!]! }
+type Query { node(id: ID!): Node }
+`)
+	if got, want := toJSON(t, doc.Components.Schemas["Node"].Required), `["id","tags"]`; got != want {
+		t.Errorf("Node required = %s, want %s", got, want)
+	}
+}
+
+func TestReturnsDirective(t *testing.T) {
+	sdl := `
+directive @returns(type: String!) on FIELD_DEFINITION
+type User { id: ID! }
+input UserInput { name: String! }
+type Query { users: [User!]!, user(id: ID!): User }
+type Mutation { createUser(input: UserInput!): User!, deleteUser(id: ID!): Boolean! @returns(type: "%s") }
+`
+	doc := convertSDL(t, DefaultConfig(), fmt.Sprintf(sdl, "User"))
+	responses := operation(t, doc, "delete", "/users/{id}").Responses
+	if _, ok := responses["204"]; ok {
+		t.Error("the 204 should become a 200")
+	}
+	if got := responses["200"].Content["application/json"].Schema.Ref; got != "#/components/schemas/User" {
+		t.Errorf("response = %s, want the User", got)
+	}
+
+	if _, err := New(DefaultConfig()).Convert(fmt.Sprintf(sdl, "Missing")); err == nil {
+		t.Error("@returns naming an unknown type should fail")
+	}
+}
+
+func TestArrayParamStyle(t *testing.T) {
+	sdl := `type Query { search(tags: [String!]): [String!]! }`
+	tests := []struct{ style, want string }{
+		{"", `{"name":"tags","in":"query","schema":{"type":"array","items":{"type":"string"}},"style":"form","explode":true}`},
+		{"form-csv", `{"name":"tags","in":"query","schema":{"type":"array","items":{"type":"string"}},"style":"form","explode":false}`},
+		{"pipeDelimited", `{"name":"tags","in":"query","schema":{"type":"array","items":{"type":"string"}},"style":"pipeDelimited","explode":false}`},
+	}
+	for _, tt := range tests {
+		config := DefaultConfig()
+		config.ArrayParamStyle = tt.style
+		doc := convertSDL(t, config, sdl)
+		if got := toJSON(t, operation(t, doc, "get", "/search").Parameters[0]); got != tt.want {
+			t.Errorf("ArrayParamStyle %q: tags = %s, want %s", tt.style, got, tt.want)
+		}
+	}
+}
+
+func TestScalarMappings(t *testing.T) {
+	config := DefaultConfig()
+	config.ScalarMappings = map[string]string{"Money": "string:decimal", "Long": "string"}
+	doc := convertSDL(t, config, `
+scalar BigInt
+scalar int64
+scalar Long
+scalar Decimal
+scalar Money
+type Account { big: BigInt, small: int64, long: Long, rate: Decimal, balance: Money }
+type Query { account: Account }
+`)
+	properties := doc.Components.Schemas["Account"].Properties
+	for name, want := range map[string]string{
+		"big":     `{"type":"integer","format":"int64"}`,
+		"small":   `{"type":"integer","format":"int64"}`,
+		"long":    `{"type":"string"}`,
+		"rate":    `{"type":"number"}`,
+		"balance": `{"type":"string","format":"decimal"}`,
+	} {
+		if got := toJSON(t, properties[name]); got != want {
+			t.Errorf("%s = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestDeprecatedTypes(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+directive @deprecated(reason: String) on FIELD_DEFINITION | ARGUMENT_DEFINITION | INPUT_FIELD_DEFINITION | ENUM_VALUE | OBJECT | INPUT_OBJECT
+type Legacy @deprecated(reason: "use User") { id: ID! }
+input LegacyInput @deprecated { id: ID! }
+type User { id: ID!, legacy: Legacy }
+type Query { user(id: ID!): User }
+type Mutation { migrate(input: LegacyInput!): User }
+`)
+	for _, name := range []string{"Legacy", "LegacyInput"} {
+		if !doc.Components.Schemas[name].Deprecated {
+			t.Errorf("%s should be deprecated", name)
+		}
+	}
+	if doc.Components.Schemas["User"].Deprecated {
+		t.Error("User should not be deprecated")
+	}
+	if got := doc.Components.Schemas["User"].Properties["legacyId"].Description; !strings.HasSuffix(got, "(Legacy is deprecated)") {
+		t.Errorf("legacyId description = %q", got)
+	}
+}
+
+func TestInlineEnums(t *testing.T) {
+	config := DefaultConfig()
+	config.InlineEnums = true
+	doc := convertSDL(t, config, `
+enum Role { ADMIN, GUEST }
+type User { id: ID!, role: Role! }
+type Query { user(id: ID!): User, search(role: Role): [User!]! }
+`)
+	want := `{"type":"string","enum":["ADMIN","GUEST"]}`
+	if got := toJSON(t, doc.Components.Schemas["User"].Properties["role"]); got != want {
+		t.Errorf("role property = %s, want %s", got, want)
+	}
+	if got := toJSON(t, operation(t, doc, "get", "/search").Parameters[0].Schema); got != want {
+		t.Errorf("role parameter = %s, want %s", got, want)
+	}
+	if _, ok := doc.Components.Schemas["Role"]; ok {
+		t.Error("the unreferenced Role component should be dropped")
+	}
+}
+
+func TestInfoDirective(t *testing.T) {
+	sdl := `
+directive @info(title: String, version: String) on SCHEMA
+schema @info(title: "Orders API", version: "3.2.0") { query: Query }
+type Query { hello: String }
+`
+	doc := convertSDL(t, DefaultConfig(), sdl)
+	if doc.Info.Title != "Orders API" || doc.Info.Version != "3.2.0" {
+		t.Errorf("info = %s, want the @info values", toJSON(t, doc.Info))
+	}
+
+	// Explicit values win, even when they repeat the defaults
+	for _, given := range []Info{{Title: "Given", Version: "9.9.9"}, {Title: "Converted from GraphQL", Version: "1.0.0"}} {
+		config := DefaultConfig()
+		WithTitle(given.Title)(&config)
+		WithVersion(given.Version)(&config)
+		doc = convertSDL(t, config, sdl)
+		if doc.Info.Title != given.Title || doc.Info.Version != given.Version {
+			t.Errorf("info = %s, want %s", toJSON(t, doc.Info), toJSON(t, given))
+		}
+	}
+}
+
+func TestSingularize(t *testing.T) {
+	config := DefaultConfig()
+	config.CustomSingulars = map[string]string{"shoes": "shoe", "caches": "cache"}
+	c := New(config)
+	for plural, want := range map[string]string{
+		"categories": "category",
+		"addresses":  "address",
+		"heroes":     "hero",
+		"users":      "user",
+		"shoes":      "shoe",
+		"caches":     "cache",
+	} {
+		if got := c.singularize(plural); got != want {
+			t.Errorf("singularize(%s) = %s, want %s", plural, got, want)
+		}
+	}
+}
+
+func TestCustomPluralsRoundTrip(t *testing.T) {
+	config := DefaultConfig()
+	config.CustomPlurals = map[string]string{"person": "people", "man": "men", "woman": "women"}
+	c := New(config)
+	for singular, plural := range map[string]string{"person": "people", "salesperson": "salespeople", "man": "men", "woman": "women"} {
+		if got := c.pluralize(singular); got != plural {
+			t.Errorf("pluralize(%s) = %s, want %s", singular, got, plural)
+		}
+		if got := c.singularize(plural); got != singular {
+			t.Errorf("singularize(%s) = %s, want %s", plural, got, singular)
+		}
+	}
+}
+
+func TestCollectionFieldAffixes(t *testing.T) {
+	for _, list := range []string{"allUsers", "listUsers", "userList"} {
+		doc := convertSDL(t, DefaultConfig(), fmt.Sprintf(`
+type User { id: ID! }
+input UserInput { name: String! }
+type Query { %s: [User!]!, user(id: ID!): User }
+type Mutation { createUser(input: UserInput!): User! }
+`, list))
+		if doc.Paths["/"+list] != nil {
+			t.Errorf("%s kept its own path", list)
+		}
+		operation(t, doc, "get", "/users")
+	}
+}
+
+func TestIDFormat(t *testing.T) {
+	sdl := `
+type User { id: ID!, managerId: ID }
+input UserInput { name: String! }
+type Query { users: [User!]!, user(id: ID!): User }
+type Mutation { createUser(input: UserInput!): User! }
+`
+	for format, want := range map[string]string{
+		"":      `{"type":"string"}`,
+		"uuid":  `{"type":"string","format":"uuid"}`,
+		"int64": `{"type":"integer","format":"int64"}`,
+	} {
+		config := DefaultConfig()
+		config.IDFormat = format
+		doc := convertSDL(t, config, sdl)
+		if got := toJSON(t, doc.Components.Schemas["User"].Properties["id"]); got != want {
+			t.Errorf("IDFormat %q: id = %s, want %s", format, got, want)
+		}
+		if got := toJSON(t, doc.Components.Parameters["IdParam"].Schema); got != want {
+			t.Errorf("IDFormat %q: IdParam = %s, want %s", format, got, want)
+		}
+	}
+}
+
+func TestBulkMutationArrayBody(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+type User { id: ID! }
+input CreateUserInput { name: String! }
+type Query { user(id: ID!): User }
+type Mutation { createUsers(inputs: [CreateUserInput!]!): [User!]! }
+`)
+	body := operation(t, doc, "post", "/createUsers").RequestBody
+	want := `{"type":"array","items":{"$ref":"#/components/schemas/CreateUserInput"}}`
+	if got := toJSON(t, body.Content["application/json"].Schema); got != want || !body.Required {
+		t.Errorf("body = %s, want a required %s", toJSON(t, body), want)
+	}
+}
+
+func TestBinaryScalars(t *testing.T) {
+	config := DefaultConfig()
+	config.ScalarMappings = map[string]string{"Blob": "string:binary"}
+	doc := convertSDL(t, config, `
+scalar Base64
+scalar Bytes
+scalar Binary
+scalar Upload
+scalar Blob
+type File { a: Base64, b: Bytes, c: Binary, d: Upload, e: Blob }
+type Query { file: File }
+`)
+	properties := doc.Components.Schemas["File"].Properties
+	for name, format := range map[string]string{"a": "byte", "b": "byte", "c": "binary", "d": "binary", "e": "binary"} {
+		if got := properties[name]; got.Type != "string" || got.Format != format {
+			t.Errorf("%s = %s, want a %s string", name, toJSON(t, got), format)
+		}
+	}
+}
+
+func TestNullableIDArgumentNotGetByID(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+type User { id: ID! }
+input UserInput { name: String! }
+type Query { users: [User!]!, user(id: ID): User }
+type Mutation { createUser(input: UserInput!): User! }
+`)
+	if doc.Paths["/users/{id}"] != nil {
+		t.Error("a nullable id should not become a path parameter")
+	}
+	param := operation(t, doc, "get", "/user").Parameters[0]
+	if param.In != "query" || param.Required {
+		t.Errorf("id = %s, want an optional query parameter", toJSON(t, param))
+	}
+}
+
+func TestPathLevelParameters(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+type Task { id: ID! }
+type Query { task(id: ID!): Task }
+type Subscription { onTask(id: ID!): Task!, onTaskFrom(id: ID!, since: String): Task! }
+`)
+	// A single operation keeps its parameters
+	if got := len(operation(t, doc, "get", "/onTask/{id}").Parameters); got != 1 {
+		t.Errorf("/onTask/{id} operation parameters = %d, want 1", got)
+	}
+	// Query parameters stay on the operation
+	if got := toJSON(t, operation(t, doc, "get", "/onTaskFrom/{id}").Parameters[1].Name); got != `"since"` {
+		t.Errorf("/onTaskFrom/{id} second parameter = %s, want since", got)
+	}
+}
+
+func TestHeaderDirective(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+directive @header(name: String!, required: Boolean, description: String) repeatable on FIELD_DEFINITION
+type Query { orders(first: Int): [String!]! @header(name: "X-Tenant-ID", required: true, description: "Tenant") @header(name: "X-Trace") }
+`)
+	params := operation(t, doc, "get", "/orders").Parameters
+	want := `[{"name":"first","in":"query","schema":{"type":"integer","format":"int32"}},` +
+		`{"name":"X-Tenant-ID","in":"header","description":"Tenant","required":true,"schema":{"type":"string"}},` +
+		`{"name":"X-Trace","in":"header","schema":{"type":"string"}}]`
+	if got := toJSON(t, params); got != want {
+		t.Errorf("parameters = %s, want %s", got, want)
+	}
+}
+
+func TestInterfaceImplementsInterface(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+interface Node { id: ID! }
+interface Content implements Node { id: ID!, body: String! }
+type Post implements Content & Node { id: ID!, body: String!, title: String }
+type Query { content(id: ID!): Content, post(id: ID!): Post }
+`)
+	want := `{"title":"Content","allOf":[{"$ref":"#/components/schemas/Node"},` +
+		`{"type":"object","properties":{"body":{"type":"string"}},"required":["body"]}]}`
+	if got := toJSON(t, doc.Components.Schemas["Content"]); got != want {
+		t.Errorf("Content = %s, want %s", got, want)
+	}
+	if got := toJSON(t, doc.Components.Schemas["Post"].AllOf[0]); got != `{"$ref":"#/components/schemas/Content"}` {
+		t.Errorf("Post composes %s first, want Content", got)
+	}
+}
+
+func TestNonNullStringArgumentsMinLength1(t *testing.T) {
+	config := DefaultConfig()
+	config.NonNullStringsMinLength1 = true
+	doc := convertSDL(t, config, `
+type User { id: ID! }
+type Query { search(q: String!, after: String, tenant: ID!): [User!]! }
+`)
+	for i, want := range []string{`{"type":"string","minLength":1}`, `{"type":"string"}`, `{"type":"string","minLength":1}`} {
+		param := operation(t, doc, "get", "/search").Parameters[i]
+		if got := toJSON(t, param.Schema); got != want {
+			t.Errorf("%s = %s, want %s", param.Name, got, want)
+		}
+	}
+}
+
+func TestFederationKey(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+directive @key(fields: String!) repeatable on OBJECT | INTERFACE
+directive @external on FIELD_DEFINITION
+type Product @key(fields: "sku") { sku: String!, name: String! @external }
+type Review { id: ID!, product: Product! }
+type Query { products: [Product!]!, product(sku: String!): Product, review(id: ID!): Review }
+type Mutation { createProduct(name: String!): Product! }
+`)
+	if got := operation(t, doc, "get", "/products/{sku}").OperationID; got != "getProduct" {
+		t.Errorf("operationId = %s, want getProduct", got)
+	}
+	if got := doc.Components.Schemas["Review"].Properties["productId"].Description; got != "Reference to Product.sku - use GET /products/{productId}" {
+		t.Errorf("productId description = %q", got)
+	}
+}
+
+func TestIncludeGraphQLEndpoint(t *testing.T) {
+	config := DefaultConfig()
+	config.IncludeGraphQLEndpoint = true
+	config.PathPrefix = "/api"
+	doc := convertSDL(t, config, `type Query { hello: String }`)
+	body := operation(t, doc, "post", "/api/graphql").RequestBody.Content["application/json"].Schema
+	want := `{"type":"object","properties":{"operationName":{"type":"string","description":"Operation to run when the document has several"},` +
+		`"query":{"type":"string","description":"GraphQL document"},"variables":{"type":"object","additionalProperties":true}},"required":["query"]}`
+	if got := toJSON(t, body); got != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+
+	if doc := convertSDL(t, DefaultConfig(), `type Query { hello: String }`); doc.Paths["/graphql"] != nil {
+		t.Error("the endpoint should only be documented when enabled")
+	}
+}
+
+func TestCollidingResourcesAreStable(t *testing.T) {
+	sdl := `
+directive @resource(name: String, path: String) on OBJECT
+type User @resource(path: "/people") { id: ID! }
+type Member @resource(path: "/people") { id: ID! }
+input UserInput { name: String! }
+input MemberInput { name: String! }
+type Query { users: [User!]!, user(id: ID!): User, members: [Member!]!, member(id: ID!): Member }
+type Mutation { createUser(input: UserInput!): User!, createMember(input: MemberInput!): Member! }
+`
+	var first []byte
+	for i := 0; i < 20; i++ {
+		doc := convertSDL(t, DefaultConfig(), sdl)
+		for path, want := range map[string]string{
+			"get /people":              "listMembers",
+			"post /people":             "createMember",
+			"get /people/{id}":         "getMember",
+			"get /people/listUsers":    "listUsers",
+			"post /people/createUser":  "createUser",
+			"get /people/{id}/getUser": "getUser",
+		} {
+			method, path, _ := strings.Cut(path, " ")
+			if got := operation(t, doc, method, path).OperationID; got != want {
+				t.Fatalf("conversion %d: %s %s = %s, want %s", i+1, method, path, got, want)
+			}
+		}
+		data, err := MarshalYAML(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if first == nil {
+			first = data
+		} else if string(data) != string(first) {
+			t.Fatalf("conversion %d differs from the first:\n%s", i+1, lineDiff(string(first), string(data)))
+		}
+	}
+}
+
+func TestCollapseSingleFieldResponses(t *testing.T) {
+	sdl := `
+type User { id: ID! }
+type UserPayload { user: User }
+type SearchPayload { users: [User!]!, total: Int! }
+type Query { viewer: UserPayload, search(q: String): SearchPayload }
+`
+	for collapse, want := range map[bool]string{false: "#/components/schemas/UserPayload", true: "#/components/schemas/User"} {
+		config := DefaultConfig()
+		config.CollapseSingleFieldResponses = collapse
+		doc := convertSDL(t, config, sdl)
+		if got := operation(t, doc, "get", "/viewer").Responses["200"].Content["application/json"].Schema.Ref; got != want {
+			t.Errorf("collapse %v: viewer responds with %s, want %s", collapse, got, want)
+		}
+		if got := operation(t, doc, "get", "/search").Responses["200"].Content["application/json"].Schema.Ref; got != "#/components/schemas/SearchPayload" {
+			t.Errorf("collapse %v: search responds with %s, want the multi-field payload kept", collapse, got)
+		}
+	}
+}
+
+func TestNullableAsOneOf(t *testing.T) {
+	sdl := `
+type User { id: ID!, nickname: String }
+type Query { user(id: ID!): User }
+`
+	tests := []struct {
+		version string
+		oneOf   bool
+		want    string
+	}{
+		{"3.1.0", true, `{"oneOf":[{"type":"string"},{"type":"null"}]}`},
+		{"3.1.0", false, `{"type":"string"}`},
+		{"3.0.0", true, `{"type":"string"}`},
+	}
+	for _, tt := range tests {
+		config := DefaultConfig()
+		config.OpenAPIVersion = tt.version
+		config.NullableAsOneOf = tt.oneOf
+		user := convertSDL(t, config, sdl).Components.Schemas["User"]
+		if got := toJSON(t, user.Properties["nickname"]); got != tt.want {
+			t.Errorf("%s, NullableAsOneOf %v: nickname = %s, want %s", tt.version, tt.oneOf, got, tt.want)
+		}
+		if got := toJSON(t, user.Properties["id"]); got != `{"type":"string"}` {
+			t.Errorf("%s, NullableAsOneOf %v: id = %s, want it left alone", tt.version, tt.oneOf, got)
+		}
+	}
+}
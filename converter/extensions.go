@@ -0,0 +1,93 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalJSON emits the operation with its Extensions inlined
+func (o Operation) MarshalJSON() ([]byte, error) {
+	type operation Operation
+	return marshalWithExtensions(operation(o), o.Extensions)
+}
+
+// MarshalJSON emits the schema with its Extensions inlined
+func (s Schema) MarshalJSON() ([]byte, error) {
+	type schema Schema
+	data, err := marshalWithExtensions(schema(s), s.Extensions)
+	if err != nil || !s.NullableType || s.Type == "" {
+		return data, err
+	}
+	// type precedes every nested schema, so its first occurrence is our own
+	single, err := json.Marshal(map[string]string{"type": s.Type})
+	if err != nil {
+		return nil, err
+	}
+	pair, err := json.Marshal(map[string][]string{"type": {s.Type, "null"}})
+	if err != nil {
+		return nil, err
+	}
+	return bytes.Replace(data, single[1:len(single)-1], pair[1:len(pair)-1], 1), nil
+}
+
+// MarshalYAML emits a NullableType schema's type as [Type, "null"]
+func (s Schema) MarshalYAML() (interface{}, error) {
+	type schema Schema
+	if !s.NullableType || s.Type == "" {
+		return schema(s), nil
+	}
+	var node yaml.Node
+	if err := node.Encode(schema(s)); err != nil {
+		return nil, err
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "type" {
+			var types yaml.Node
+			if err := types.Encode([]string{s.Type, "null"}); err != nil {
+				return nil, err
+			}
+			node.Content[i+1] = &types
+			break
+		}
+	}
+	return &node, nil
+}
+
+// marshalWithExtensions marshals v, a JSON object, and appends the extensions
+// to it in key order, as yaml.v3 does for an inline map
+func marshalWithExtensions(v interface{}, extensions map[string]interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extensions) == 0 {
+		return data, err
+	}
+
+	keys := make([]string, 0, len(extensions))
+	for key := range extensions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1])
+	for i, key := range keys {
+		if i > 0 || len(data) > 2 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(extensions[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
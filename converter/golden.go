@@ -0,0 +1,23 @@
+package converter
+
+import (
+	"fmt"
+	"os"
+)
+
+// ConvertGolden converts the schema file at schemaPath with DefaultConfig and
+// returns the YAML output, for comparison against a checked-in golden file
+// (e.g. examples/*/openapi.yaml)
+func ConvertGolden(schemaPath string) ([]byte, error) {
+	schemaBytes, err := os.ReadFile(schemaPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema file: %w", err)
+	}
+
+	doc, err := New(DefaultConfig()).Convert(string(schemaBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	return MarshalYAML(doc)
+}
@@ -0,0 +1,58 @@
+package converter
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConvertGolden(t *testing.T) {
+	schemas, err := filepath.Glob("../examples/*/schema.graphql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(schemas) == 0 {
+		t.Fatal("no examples/*/schema.graphql found")
+	}
+
+	for _, schemaPath := range schemas {
+		dir := filepath.Dir(schemaPath)
+		t.Run(filepath.Base(dir), func(t *testing.T) {
+			want, err := os.ReadFile(filepath.Join(dir, "openapi.yaml"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ConvertGolden(schemaPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("output differs from %s/openapi.yaml; regenerate with `make examples` if the change is intended\n%s",
+					dir, lineDiff(string(want), string(got)))
+			}
+		})
+	}
+}
+
+// lineDiff reports the first line where want and got differ, which is enough
+// to locate a golden file regression
+func lineDiff(want, got string) string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			return fmt.Sprintf("line %d:\n- %s\n+ %s", i+1, w, g)
+		}
+	}
+	return ""
+}
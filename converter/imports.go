@@ -0,0 +1,55 @@
+package converter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// importComment matches `# import "./types.graphql"` and the graphql-import
+// form `# import User, Post from "./types.graphql"`
+var importComment = regexp.MustCompile(`^\s*#\s*import\s+(?:.*\s+from\s+)?["']([^"']+)["']`)
+
+// LoadSDL reads the schema SDL at path, appending the files named by its
+// `# import` comments (resolved relative to the importing file, recursively).
+// The root file comes first so its header still describes the schema, and each
+// file is included once, so circular imports end where they loop back.
+func LoadSDL(path string) (string, error) {
+	var sdl strings.Builder
+	if err := loadSDL(path, map[string]bool{}, &sdl); err != nil {
+		return "", err
+	}
+	return sdl.String(), nil
+}
+
+func loadSDL(path string, loaded map[string]bool, sdl *strings.Builder) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if loaded[abs] {
+		return nil
+	}
+	loaded[abs] = true
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	sdl.Write(data)
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		sdl.WriteByte('\n')
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if match := importComment.FindStringSubmatch(line); match != nil {
+			imported := filepath.Join(filepath.Dir(path), match[1])
+			if err := loadSDL(imported, loaded, sdl); err != nil {
+				return fmt.Errorf("%s imports %s: %w", path, match[1], err)
+			}
+		}
+	}
+	return nil
+}
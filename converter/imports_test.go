@@ -0,0 +1,49 @@
+package converter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadSDLKeepsRootHeader(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"schema.graphql": `# Root API
+# import "./types.graphql"
+
+type Query {
+  users: [User!]!
+}
+`,
+		"types.graphql": `# Types header
+# import "./schema.graphql"
+
+type User {
+  id: ID!
+}
+`,
+	}
+	for name, body := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sdl, err := LoadSDL(filepath.Join(dir, "schema.graphql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(sdl, "# Root API") {
+		t.Errorf("LoadSDL should start with the root file, got:\n%s", sdl)
+	}
+	if strings.Count(sdl, "type Query") != 1 || strings.Count(sdl, "type User") != 1 {
+		t.Errorf("each file should be included once, got:\n%s", sdl)
+	}
+
+	doc := convertSDL(t, Config{OmitFooter: true}, sdl)
+	if doc.Info.Description != "Root API" {
+		t.Errorf("description = %q, want %q", doc.Info.Description, "Root API")
+	}
+}
@@ -0,0 +1,260 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// introspectionSchema mirrors the __schema object of a standard introspection query result
+type introspectionSchema struct {
+	QueryType        *introspectionName       `json:"queryType"`
+	MutationType     *introspectionName       `json:"mutationType"`
+	SubscriptionType *introspectionName       `json:"subscriptionType"`
+	Types            []introspectionType      `json:"types"`
+	Directives       []introspectionDirective `json:"directives"`
+}
+
+type introspectionName struct {
+	Name string `json:"name"`
+}
+
+type introspectionType struct {
+	Kind           string                 `json:"kind"`
+	Name           string                 `json:"name"`
+	Description    string                 `json:"description"`
+	Fields         []introspectionField   `json:"fields"`
+	InputFields    []introspectionInput   `json:"inputFields"`
+	Interfaces     []introspectionTypeRef `json:"interfaces"`
+	EnumValues     []introspectionEnum    `json:"enumValues"`
+	PossibleTypes  []introspectionTypeRef `json:"possibleTypes"`
+	SpecifiedByURL string                 `json:"specifiedByURL"`
+	IsOneOf        bool                   `json:"isOneOf"`
+}
+
+type introspectionField struct {
+	Name              string               `json:"name"`
+	Description       string               `json:"description"`
+	Args              []introspectionInput `json:"args"`
+	Type              introspectionTypeRef `json:"type"`
+	IsDeprecated      bool                 `json:"isDeprecated"`
+	DeprecationReason string               `json:"deprecationReason"`
+}
+
+type introspectionInput struct {
+	Name         string               `json:"name"`
+	Description  string               `json:"description"`
+	Type         introspectionTypeRef `json:"type"`
+	DefaultValue *string              `json:"defaultValue"`
+}
+
+type introspectionEnum struct {
+	Name              string `json:"name"`
+	Description       string `json:"description"`
+	IsDeprecated      bool   `json:"isDeprecated"`
+	DeprecationReason string `json:"deprecationReason"`
+}
+
+type introspectionTypeRef struct {
+	Kind   string                `json:"kind"`
+	Name   string                `json:"name"`
+	OfType *introspectionTypeRef `json:"ofType"`
+}
+
+type introspectionDirective struct {
+	Name         string               `json:"name"`
+	Description  string               `json:"description"`
+	Locations    []string             `json:"locations"`
+	Args         []introspectionInput `json:"args"`
+	IsRepeatable bool                 `json:"isRepeatable"`
+}
+
+// preludeDirectives are declared by the GraphQL parser itself
+var preludeDirectives = map[string]bool{
+	"defer": true, "include": true, "skip": true, "deprecated": true, "specifiedBy": true, "oneOf": true,
+}
+
+// IntrospectionToSDL converts the JSON result of a standard introspection query
+// (either {"data": {"__schema": ...}} or {"__schema": ...}) into schema SDL,
+// ready to pass to Convert
+func IntrospectionToSDL(data []byte) (string, error) {
+	var result struct {
+		Data *struct {
+			Schema *introspectionSchema `json:"__schema"`
+		} `json:"data"`
+		Schema *introspectionSchema `json:"__schema"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return "", fmt.Errorf("failed to parse introspection JSON: %w", err)
+	}
+	schema := result.Schema
+	if result.Data != nil && result.Data.Schema != nil {
+		schema = result.Data.Schema
+	}
+	if schema == nil {
+		return "", fmt.Errorf("introspection JSON has no __schema")
+	}
+
+	var sdl strings.Builder
+
+	// Root operation types only need declaring when not named Query/Mutation/Subscription
+	roots := []string{}
+	customRoots := false
+	for _, root := range []struct {
+		operation, defaultName string
+		typ                    *introspectionName
+	}{
+		{"query", "Query", schema.QueryType},
+		{"mutation", "Mutation", schema.MutationType},
+		{"subscription", "Subscription", schema.SubscriptionType},
+	} {
+		if root.typ != nil && root.typ.Name != "" {
+			roots = append(roots, "  "+root.operation+": "+root.typ.Name)
+			customRoots = customRoots || root.typ.Name != root.defaultName
+		}
+	}
+	if customRoots {
+		sdl.WriteString("schema {\n" + strings.Join(roots, "\n") + "\n}\n\n")
+	}
+
+	for _, directive := range schema.Directives {
+		if preludeDirectives[directive.Name] {
+			continue
+		}
+		writeDescription(&sdl, directive.Description, "")
+		sdl.WriteString("directive @" + directive.Name + formatArgs(directive.Args))
+		if directive.IsRepeatable {
+			sdl.WriteString(" repeatable")
+		}
+		sdl.WriteString(" on " + strings.Join(directive.Locations, " | ") + "\n\n")
+	}
+
+	for _, t := range schema.Types {
+		if strings.HasPrefix(t.Name, "__") || isScalarType(t.Name) {
+			continue
+		}
+		writeDescription(&sdl, t.Description, "")
+		switch t.Kind {
+		case "SCALAR":
+			sdl.WriteString("scalar " + t.Name)
+			if t.SpecifiedByURL != "" {
+				sdl.WriteString(" @specifiedBy(url: " + quoteGraphQL(t.SpecifiedByURL) + ")")
+			}
+			sdl.WriteString("\n\n")
+		case "OBJECT", "INTERFACE":
+			keyword := "type "
+			if t.Kind == "INTERFACE" {
+				keyword = "interface "
+			}
+			sdl.WriteString(keyword + t.Name)
+			if len(t.Interfaces) > 0 {
+				names := []string{}
+				for _, iface := range t.Interfaces {
+					names = append(names, iface.Name)
+				}
+				sdl.WriteString(" implements " + strings.Join(names, " & "))
+			}
+			sdl.WriteString(" {\n")
+			for _, field := range t.Fields {
+				writeDescription(&sdl, field.Description, "  ")
+				sdl.WriteString("  " + field.Name + formatArgs(field.Args) + ": " + field.Type.String())
+				writeDeprecated(&sdl, field.IsDeprecated, field.DeprecationReason)
+				sdl.WriteString("\n")
+			}
+			sdl.WriteString("}\n\n")
+		case "UNION":
+			names := []string{}
+			for _, member := range t.PossibleTypes {
+				names = append(names, member.Name)
+			}
+			sdl.WriteString("union " + t.Name + " = " + strings.Join(names, " | ") + "\n\n")
+		case "ENUM":
+			sdl.WriteString("enum " + t.Name + " {\n")
+			for _, value := range t.EnumValues {
+				writeDescription(&sdl, value.Description, "  ")
+				sdl.WriteString("  " + value.Name)
+				writeDeprecated(&sdl, value.IsDeprecated, value.DeprecationReason)
+				sdl.WriteString("\n")
+			}
+			sdl.WriteString("}\n\n")
+		case "INPUT_OBJECT":
+			sdl.WriteString("input " + t.Name)
+			if t.IsOneOf {
+				sdl.WriteString(" @oneOf")
+			}
+			sdl.WriteString(" {\n")
+			for _, field := range t.InputFields {
+				writeDescription(&sdl, field.Description, "  ")
+				sdl.WriteString("  " + formatInput(field) + "\n")
+			}
+			sdl.WriteString("}\n\n")
+		default:
+			return "", fmt.Errorf("unsupported introspection type kind %q for %s", t.Kind, t.Name)
+		}
+	}
+
+	return sdl.String(), nil
+}
+
+// String renders a type reference in SDL form, e.g. [User!]!
+func (t introspectionTypeRef) String() string {
+	switch t.Kind {
+	case "NON_NULL":
+		if t.OfType != nil {
+			return t.OfType.String() + "!"
+		}
+	case "LIST":
+		if t.OfType != nil {
+			return "[" + t.OfType.String() + "]"
+		}
+	}
+	return t.Name
+}
+
+func formatArgs(args []introspectionInput) string {
+	if len(args) == 0 {
+		return ""
+	}
+	rendered := []string{}
+	for _, arg := range args {
+		if arg.Description != "" {
+			rendered = append(rendered, quoteGraphQL(arg.Description)+" "+formatInput(arg))
+		} else {
+			rendered = append(rendered, formatInput(arg))
+		}
+	}
+	return "(" + strings.Join(rendered, ", ") + ")"
+}
+
+func formatInput(input introspectionInput) string {
+	s := input.Name + ": " + input.Type.String()
+	if input.DefaultValue != nil {
+		s += " = " + *input.DefaultValue
+	}
+	return s
+}
+
+func writeDescription(sdl *strings.Builder, description, indent string) {
+	if description == "" {
+		return
+	}
+	sdl.WriteString(indent + quoteGraphQL(description) + "\n")
+}
+
+func writeDeprecated(sdl *strings.Builder, deprecated bool, reason string) {
+	if !deprecated {
+		return
+	}
+	if reason == "" {
+		sdl.WriteString(" @deprecated")
+		return
+	}
+	sdl.WriteString(" @deprecated(reason: " + quoteGraphQL(reason) + ")")
+}
+
+// quoteGraphQL renders s as a GraphQL string literal
+func quoteGraphQL(s string) string {
+	// JSON string escapes are valid GraphQL string escapes
+	quoted, _ := json.Marshal(s)
+	return string(quoted)
+}
@@ -0,0 +1,42 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIntrospectionToSDL(t *testing.T) {
+	result := `{"data": {"__schema": {
+  "queryType": {"name": "Query"},
+  "types": [
+    {"kind": "OBJECT", "name": "Query", "fields": [
+      {"name": "user", "args": [{"name": "id", "type": {"kind": "NON_NULL", "ofType": {"kind": "SCALAR", "name": "ID"}}}],
+       "type": {"kind": "OBJECT", "name": "User"}}
+    ]},
+    {"kind": "OBJECT", "name": "User", "description": "A user", "fields": [
+      {"name": "id", "args": [], "type": {"kind": "NON_NULL", "ofType": {"kind": "SCALAR", "name": "ID"}}},
+      {"name": "login", "args": [], "type": {"kind": "SCALAR", "name": "String"}, "isDeprecated": true, "deprecationReason": "use id"}
+    ]},
+    {"kind": "SCALAR", "name": "ID"},
+    {"kind": "SCALAR", "name": "String"}
+  ],
+  "directives": []
+}}}`
+	sdl, err := IntrospectionToSDL([]byte(result))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"user(id: ID!): User", `login: String @deprecated(reason: "use id")`} {
+		if !strings.Contains(sdl, want) {
+			t.Errorf("SDL lacks %q:\n%s", want, sdl)
+		}
+	}
+	doc := convertSDL(t, DefaultConfig(), sdl)
+	if doc.Components.Schemas["User"].Description != "A user" {
+		t.Errorf("User = %s, want its description", toJSON(t, doc.Components.Schemas["User"]))
+	}
+
+	if _, err := IntrospectionToSDL([]byte(`{"data": {}}`)); err == nil {
+		t.Error("a result without __schema should fail")
+	}
+}
@@ -0,0 +1,79 @@
+package converter
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// MarshalJSONSchema writes the document's component schemas as a standalone
+// JSON Schema (draft 2020-12) document, with every schema under $defs and
+// $refs rewritten to point there. OpenAPI 3.0's nullable becomes a
+// {type: "null"} alternative; paths and operations are left out.
+func MarshalJSONSchema(doc *OpenAPIDocument) ([]byte, error) {
+	schemas := map[string]*Schema{}
+	if doc.Components != nil && doc.Components.Schemas != nil {
+		schemas = doc.Components.Schemas
+	}
+	data, err := json.Marshal(schemas)
+	if err != nil {
+		return nil, err
+	}
+	data = []byte(strings.ReplaceAll(string(data), `"#/components/schemas/`, `"#/$defs/`))
+
+	var defs map[string]interface{}
+	if err := json.Unmarshal(data, &defs); err != nil {
+		return nil, err
+	}
+	for name, def := range defs {
+		defs[name] = rewriteOpenAPI30(def)
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"$schema": jsonSchemaDialect,
+		"$defs":   defs,
+	}, "", "  ")
+}
+
+// rewriteOpenAPI30 replaces {nullable: true, ...} with a oneOf of the schema
+// and {type: "null"}, and {minimum: 0, exclusiveMinimum: true} with
+// {exclusiveMinimum: 0}, recursing into nested schemas
+func rewriteOpenAPI30(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			v[key] = rewriteOpenAPI30(child)
+		}
+		for exclusive, bound := range map[string]string{"exclusiveMinimum": "minimum", "exclusiveMaximum": "maximum"} {
+			if flag, ok := v[exclusive].(bool); ok {
+				delete(v, exclusive)
+				if flag {
+					v[exclusive] = v[bound]
+					delete(v, bound)
+				}
+			}
+		}
+		if nullable, _ := v["nullable"].(bool); nullable {
+			delete(v, "nullable")
+			wrapped := map[string]interface{}{}
+			// Annotations stay on the outer schema
+			for _, key := range []string{"description", "deprecated", "readOnly", "writeOnly"} {
+				if annotation, ok := v[key]; ok {
+					wrapped[key] = annotation
+					delete(v, key)
+				}
+			}
+			wrapped["oneOf"] = []interface{}{v, map[string]interface{}{"type": "null"}}
+			return wrapped
+		}
+		return v
+	case []interface{}:
+		for i, child := range v {
+			v[i] = rewriteOpenAPI30(child)
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,65 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshalJSONSchemaRewritesOpenAPI30(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), constraintDirective+`
+type Product { id: ID!, price: Float! @constraint(exclusiveMin: 0) }
+type Query { product(id: ID!): Product }
+`)
+	data, err := MarshalJSONSchema(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out struct {
+		Defs map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	var price bytes.Buffer
+	if err := json.Compact(&price, out.Defs["Product"].Properties["price"]); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := price.String(), `{"exclusiveMinimum":0,"format":"double","type":"number"}`; got != want {
+		t.Errorf("price = %s, want %s", got, want)
+	}
+}
+
+func TestMarshalJSONSchema(t *testing.T) {
+	doc := convertSDL(t, DefaultConfig(), `
+enum Role { ADMIN }
+type User { id: ID!, role: Role, nickname: String }
+type Query { user(id: ID!): User }
+`)
+	data, err := MarshalJSONSchema(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out struct {
+		Schema string                     `json:"$schema"`
+		Defs   map[string]json.RawMessage `json:"$defs"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Schema != jsonSchemaDialect {
+		t.Errorf("$schema = %s, want %s", out.Schema, jsonSchemaDialect)
+	}
+	if len(out.Defs) != 2 {
+		t.Errorf("$defs has %d schemas, want Role and User", len(out.Defs))
+	}
+	var user bytes.Buffer
+	if err := json.Compact(&user, out.Defs["User"]); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(user.Bytes(), []byte(`{"oneOf":[{"allOf":[{"$ref":"#/$defs/Role"}]},{"type":"null"}]}`)) {
+		t.Errorf("User = %s, want the nullable role as a oneOf of a $defs reference and null", user.String())
+	}
+}
@@ -0,0 +1,130 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Warning is a style finding reported by Lint
+type Warning struct {
+	Location string `json:"location"` // e.g. "GET /users" or "components.schemas.User"
+	Message  string `json:"message"`
+}
+
+func (w Warning) String() string {
+	return w.Location + ": " + w.Message
+}
+
+// componentRef matches $ref targets inside components
+var componentRef = regexp.MustCompile(`"\$ref":"#/components/(schemas|parameters|responses)/([^"]+)"`)
+
+// Lint reports style issues in a generated document: operations without
+// summaries, paths without a 2xx response, schemas without descriptions,
+// single-value enums and unused components. Findings are sorted by location.
+func Lint(doc *OpenAPIDocument) []Warning {
+	warnings := []Warning{}
+
+	for _, path := range sortedPaths(doc) {
+		for _, po := range pathOperations(doc.Paths[path]) {
+			location := strings.ToUpper(po.Method) + " " + path
+			if po.Operation.Summary == "" {
+				warnings = append(warnings, Warning{location, "operation has no summary"})
+			}
+			success := false
+			for code := range po.Operation.Responses {
+				if strings.HasPrefix(code, "2") {
+					success = true
+				}
+			}
+			if !success {
+				warnings = append(warnings, Warning{location, "operation has no 2xx response"})
+			}
+		}
+	}
+
+	names := make([]string, 0, len(doc.Components.Schemas))
+	for name := range doc.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		schema := doc.Components.Schemas[name]
+		location := "components.schemas." + name
+		if schema.Description == "" {
+			warnings = append(warnings, Warning{location, "schema has no description"})
+		}
+		if len(schema.Enum) == 1 {
+			warnings = append(warnings, Warning{location, fmt.Sprintf("enum has a single value (%s)", schema.Enum[0])})
+		}
+	}
+
+	used := usedComponents(doc)
+	for _, name := range names {
+		if !used["schemas/"+name] {
+			warnings = append(warnings, Warning{"components.schemas." + name, "schema is never referenced"})
+		}
+	}
+	parameters := make([]string, 0, len(doc.Components.Parameters))
+	for name := range doc.Components.Parameters {
+		parameters = append(parameters, name)
+	}
+	sort.Strings(parameters)
+	for _, name := range parameters {
+		if !used["parameters/"+name] {
+			warnings = append(warnings, Warning{"components.parameters." + name, "parameter is never referenced"})
+		}
+	}
+	responses := make([]string, 0, len(doc.Components.Responses))
+	for name := range doc.Components.Responses {
+		responses = append(responses, name)
+	}
+	sort.Strings(responses)
+	for _, name := range responses {
+		if !used["responses/"+name] {
+			warnings = append(warnings, Warning{"components.responses." + name, "response is never referenced"})
+		}
+	}
+
+	sort.SliceStable(warnings, func(i, j int) bool {
+		return warnings[i].Location < warnings[j].Location
+	})
+	return warnings
+}
+
+// usedComponents returns the components reachable from the document's paths,
+// keyed as "schemas/Name", "parameters/Name" or "responses/Name"
+func usedComponents(doc *OpenAPIDocument) map[string]bool {
+	used := make(map[string]bool)
+	pending := append(refsIn(doc.Paths), refsIn(doc.Webhooks)...)
+	for len(pending) > 0 {
+		ref := pending[0]
+		pending = pending[1:]
+		if used[ref] {
+			continue
+		}
+		used[ref] = true
+		if name, ok := strings.CutPrefix(ref, "schemas/"); ok && doc.Components.Schemas[name] != nil {
+			pending = append(pending, refsIn(doc.Components.Schemas[name])...)
+		}
+		if name, ok := strings.CutPrefix(ref, "responses/"); ok && doc.Components.Responses[name] != nil {
+			pending = append(pending, refsIn(doc.Components.Responses[name])...)
+		}
+	}
+	return used
+}
+
+// refsIn returns the component refs ("schemas/Name") found anywhere in v
+func refsIn(v interface{}) []string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	refs := []string{}
+	for _, match := range componentRef.FindAllStringSubmatch(string(data), -1) {
+		refs = append(refs, match[1]+"/"+match[2])
+	}
+	return refs
+}
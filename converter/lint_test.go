@@ -0,0 +1,31 @@
+package converter
+
+import (
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	doc := &OpenAPIDocument{
+		Paths: map[string]*PathItem{
+			"/users": {Get: &Operation{Responses: map[string]*Response{"404": {Description: "Not found"}}}},
+		},
+		Components: &Components{
+			Schemas: map[string]*Schema{
+				"Status": {Type: "string", Enum: []string{"ACTIVE"}, Description: "Status"},
+			},
+		},
+	}
+	want := []string{
+		"GET /users: operation has no summary",
+		"GET /users: operation has no 2xx response",
+		"components.schemas.Status: enum has a single value (ACTIVE)",
+		"components.schemas.Status: schema is never referenced",
+	}
+	got := []string{}
+	for _, warning := range Lint(doc) {
+		got = append(got, warning.String())
+	}
+	if toJSON(t, got) != toJSON(t, want) {
+		t.Errorf("Lint = %s, want %s", toJSON(t, got), toJSON(t, want))
+	}
+}
@@ -0,0 +1,84 @@
+package converter
+
+// DefaultConfig returns the configuration used by the CLI when no flags are given
+func DefaultConfig() Config {
+	return Config{
+		Title:                  "Converted from GraphQL",
+		Version:                "1.0.0",
+		DetectRESTPatterns:     true,
+		PluralizeSuffixesES:    []string{"s", "x", "z", "ch", "sh"},
+		PluralizeSuffixIES:     "y",
+		PluralizeDefaultSuffix: "s",
+		CRUDPrefixCreate:       "create",
+		CRUDPrefixUpdate:       "update",
+		CRUDPrefixDelete:       "delete",
+		ResourceIDFieldNames:   []string{"id"},
+		CollectionFieldAffixes: []string{"all", "list"},
+		ResponseContentTypes:   []string{"application/json"},
+	}
+}
+
+// Option adjusts the Config built by NewWithOptions
+type Option func(*Config)
+
+// NewWithOptions creates a converter starting from DefaultConfig (the CLI's
+// defaults for title, version, pluralization and CRUD prefixes), then applies
+// each option in order
+func NewWithOptions(opts ...Option) *Converter {
+	config := DefaultConfig()
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return New(config)
+}
+
+// WithTitle sets the API title (info.title)
+func WithTitle(title string) Option {
+	return func(c *Config) { c.Title, c.TitleSet = title, true }
+}
+
+// WithVersion sets the API version (info.version)
+func WithVersion(version string) Option {
+	return func(c *Config) { c.Version, c.VersionSet = version, true }
+}
+
+// WithPathPrefix prefixes every path (e.g. "/api/v1")
+func WithPathPrefix(prefix string) Option {
+	return func(c *Config) { c.PathPrefix = prefix }
+}
+
+// WithPathBuilder rewrites each generated path with builder (see PathContext)
+func WithPathBuilder(builder func(PathContext) string) Option {
+	return func(c *Config) { c.PathBuilder = builder }
+}
+
+// WithCustomPlurals adds irregular plurals (e.g. "person": "people"), keeping
+// any set by earlier options
+func WithCustomPlurals(plurals map[string]string) Option {
+	return func(c *Config) {
+		if c.CustomPlurals == nil {
+			c.CustomPlurals = make(map[string]string)
+		}
+		for singular, plural := range plurals {
+			c.CustomPlurals[singular] = plural
+		}
+	}
+}
+
+// WithCustomSingulars adds irregular singulars (e.g. "shoes": "shoe"), keeping
+// any set by earlier options
+func WithCustomSingulars(singulars map[string]string) Option {
+	return func(c *Config) {
+		if c.CustomSingulars == nil {
+			c.CustomSingulars = make(map[string]string)
+		}
+		for plural, singular := range singulars {
+			c.CustomSingulars[plural] = singular
+		}
+	}
+}
+
+// WithRESTDetection turns REST pattern detection on or off (default on)
+func WithRESTDetection(enabled bool) Option {
+	return func(c *Config) { c.DetectRESTPatterns = enabled }
+}
@@ -0,0 +1,29 @@
+package converter
+
+import (
+	"testing"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	c := NewWithOptions(
+		WithTitle("My API"),
+		WithVersion("2.0.0"),
+		WithPathPrefix("/api/v2"),
+		WithCustomPlurals(map[string]string{"person": "people"}),
+		func(config *Config) { config.OmitFooter = true },
+	)
+	doc, err := c.Convert(`
+type Person { id: ID! }
+input PersonInput { name: String! }
+type Query { people: [Person!]!, person(id: ID!): Person }
+type Mutation { createPerson(input: PersonInput!): Person! }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Info.Title != "My API" || doc.Info.Version != "2.0.0" || doc.Info.Description != "" {
+		t.Errorf("info = %s", toJSON(t, doc.Info))
+	}
+	// The CLI defaults still apply, including REST detection
+	operation(t, doc, "get", "/api/v2/people/{id}")
+}
@@ -0,0 +1,242 @@
+package converter
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+const postmanSchemaURL = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// postmanCollection is a Postman v2.1 collection
+type postmanCollection struct {
+	Info     postmanInfo       `json:"info"`
+	Item     []postmanFolder   `json:"item"`
+	Variable []postmanVariable `json:"variable,omitempty"`
+}
+
+type postmanInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Schema      string `json:"schema"`
+}
+
+type postmanFolder struct {
+	Name string        `json:"name"`
+	Item []postmanItem `json:"item"`
+}
+
+type postmanItem struct {
+	Name    string         `json:"name"`
+	Request postmanRequest `json:"request"`
+}
+
+type postmanRequest struct {
+	Method      string          `json:"method"`
+	Header      []postmanHeader `json:"header"`
+	URL         postmanURL      `json:"url"`
+	Body        *postmanBody    `json:"body,omitempty"`
+	Description string          `json:"description,omitempty"`
+}
+
+type postmanHeader struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type postmanURL struct {
+	Raw      string            `json:"raw"`
+	Host     []string          `json:"host"`
+	Path     []string          `json:"path"`
+	Query    []postmanQuery    `json:"query,omitempty"`
+	Variable []postmanVariable `json:"variable,omitempty"`
+}
+
+type postmanQuery struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"` // Optional parameters start disabled
+}
+
+type postmanVariable struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+}
+
+type postmanBody struct {
+	Mode    string             `json:"mode"`
+	Raw     string             `json:"raw"`
+	Options postmanBodyOptions `json:"options"`
+}
+
+type postmanBodyOptions struct {
+	Raw struct {
+		Language string `json:"language"`
+	} `json:"raw"`
+}
+
+// MarshalPostman converts an OpenAPI document to a Postman v2.1 collection.
+// Operations are foldered by their first tag, path parameters become :name
+// URL variables and JSON request bodies are filled with their example (or a
+// placeholder built from the schema's required fields). URLs start with a
+// {{baseUrl}} collection variable set to the first server.
+func MarshalPostman(doc *OpenAPIDocument) ([]byte, error) {
+	collection := postmanCollection{
+		Info: postmanInfo{
+			Name:        doc.Info.Title,
+			Description: doc.Info.Description,
+			Schema:      postmanSchemaURL,
+		},
+		Item: []postmanFolder{},
+	}
+	baseURL := ""
+	if len(doc.Servers) > 0 {
+		baseURL = doc.Servers[0].URL
+	}
+	collection.Variable = []postmanVariable{{Key: "baseUrl", Value: baseURL}}
+
+	// Folders follow the document's tag order, then first use
+	folders := make(map[string]int)
+	for _, tag := range doc.Tags {
+		folders[tag.Name] = len(collection.Item)
+		collection.Item = append(collection.Item, postmanFolder{Name: tag.Name, Item: []postmanItem{}})
+	}
+	examples := &Converter{doc: doc}
+
+	for _, path := range sortedPaths(doc) {
+		for _, po := range pathOperations(doc.Paths[path]) {
+			op := po.Operation
+			folder := "default"
+			if len(op.Tags) > 0 {
+				folder = op.Tags[0]
+			}
+			if _, ok := folders[folder]; !ok {
+				folders[folder] = len(collection.Item)
+				collection.Item = append(collection.Item, postmanFolder{Name: folder, Item: []postmanItem{}})
+			}
+
+			name := op.Summary
+			if name == "" {
+				name = op.OperationID
+			}
+			request := postmanRequest{
+				Method:      strings.ToUpper(po.Method),
+				Header:      []postmanHeader{},
+				Description: op.Description,
+			}
+
+			segments := []string{}
+			for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+				if param, ok := strings.CutPrefix(segment, "{"); ok {
+					segment = ":" + strings.TrimSuffix(param, "}")
+				}
+				segments = append(segments, segment)
+			}
+			request.URL = postmanURL{
+				Raw:  "{{baseUrl}}/" + strings.Join(segments, "/"),
+				Host: []string{"{{baseUrl}}"},
+				Path: segments,
+			}
+			// Path-level parameters apply to every operation on the path
+			params := append(append([]*Parameter{}, doc.Paths[path].Parameters...), op.Parameters...)
+			for _, param := range params {
+				param = resolveParameter(doc, param)
+				if param == nil {
+					continue
+				}
+				switch param.In {
+				case "path":
+					request.URL.Variable = append(request.URL.Variable, postmanVariable{Key: param.Name, Description: param.Description})
+				case "query":
+					request.URL.Query = append(request.URL.Query, postmanQuery{Key: param.Name, Description: param.Description, Disabled: !param.Required})
+				case "header":
+					request.Header = append(request.Header, postmanHeader{Key: param.Name})
+				}
+			}
+			for _, query := range request.URL.Query {
+				if !query.Disabled {
+					request.URL.Raw += queryJoiner(request.URL.Raw) + query.Key + "="
+				}
+			}
+
+			if op.RequestBody != nil {
+				if contentType, media := requestMediaType(op.RequestBody.Content); media != nil {
+					body := &postmanBody{Mode: "raw"}
+					body.Options.Raw.Language = "text"
+					if isJSONMediaType(contentType) {
+						example := media.Example
+						if example == nil && media.Schema != nil {
+							example = examples.minimalExample(media.Schema, map[string]bool{})
+						}
+						raw, err := json.MarshalIndent(example, "", "  ")
+						if err != nil {
+							return nil, err
+						}
+						body.Raw = string(raw)
+						body.Options.Raw.Language = "json"
+					} else if strings.Contains(contentType, "xml") {
+						body.Options.Raw.Language = "xml"
+					}
+					request.Body = body
+					request.Header = append(request.Header, postmanHeader{Key: "Content-Type", Value: contentType})
+				}
+			}
+
+			index := folders[folder]
+			collection.Item[index].Item = append(collection.Item[index].Item, postmanItem{Name: name, Request: request})
+		}
+	}
+
+	// Tags without operations would be empty folders
+	nonEmpty := []postmanFolder{}
+	for _, folder := range collection.Item {
+		if len(folder.Item) > 0 {
+			nonEmpty = append(nonEmpty, folder)
+		}
+	}
+	collection.Item = nonEmpty
+
+	return json.MarshalIndent(collection, "", "  ")
+}
+
+// requestMediaType picks the request body to put in the collection:
+// application/json, else the first JSON based type, else the first type
+func requestMediaType(content map[string]*MediaType) (string, *MediaType) {
+	if media := content["application/json"]; media != nil {
+		return "application/json", media
+	}
+	contentTypes := make([]string, 0, len(content))
+	for contentType := range content {
+		contentTypes = append(contentTypes, contentType)
+	}
+	sort.Strings(contentTypes)
+	for _, contentType := range contentTypes {
+		if isJSONMediaType(contentType) {
+			return contentType, content[contentType]
+		}
+	}
+	if len(contentTypes) == 0 {
+		return "", nil
+	}
+	return contentTypes[0], content[contentTypes[0]]
+}
+
+// resolveParameter follows a #/components/parameters reference
+func resolveParameter(doc *OpenAPIDocument, param *Parameter) *Parameter {
+	if param.Ref == "" {
+		return param
+	}
+	if doc.Components == nil {
+		return nil
+	}
+	return doc.Components.Parameters[strings.TrimPrefix(param.Ref, "#/components/parameters/")]
+}
+
+func queryJoiner(raw string) string {
+	if strings.Contains(raw, "?") {
+		return "&"
+	}
+	return "?"
+}
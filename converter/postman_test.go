@@ -0,0 +1,48 @@
+package converter
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshalPostman(t *testing.T) {
+	config := DefaultConfig()
+	config.BaseURL = "https://api.example.com"
+	doc := convertSDL(t, config, `
+type User { id: ID!, name: String! }
+input UserInput { name: String! }
+type Query { users: [User!]!, user(id: ID!): User, searchUsers(q: String): [User!]! }
+type Mutation { createUser(input: UserInput!): User! }
+`)
+	output, err := MarshalPostman(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var collection postmanCollection
+	if err := json.Unmarshal(output, &collection); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := toJSON(t, collection.Variable), `[{"key":"baseUrl","value":"https://api.example.com"}]`; got != want {
+		t.Errorf("variables = %s, want %s", got, want)
+	}
+	folders := []string{}
+	requests := map[string]postmanRequest{}
+	for _, folder := range collection.Item {
+		folders = append(folders, folder.Name)
+		for _, item := range folder.Item {
+			requests[item.Request.Method+" "+item.Request.URL.Raw] = item.Request
+		}
+	}
+	if got, want := toJSON(t, folders), `["User","users"]`; got != want {
+		t.Errorf("folders = %s, want %s", got, want)
+	}
+	if search, ok := requests["GET {{baseUrl}}/searchUsers"]; !ok || len(search.URL.Query) != 1 || !search.URL.Query[0].Disabled {
+		t.Errorf("search request = %s, want a disabled q query", toJSON(t, search))
+	}
+	if get, ok := requests["GET {{baseUrl}}/users/:id"]; !ok || len(get.URL.Variable) != 1 || get.URL.Variable[0].Key != "id" {
+		t.Errorf("get request = %s, want an id variable", toJSON(t, get))
+	}
+	if create, ok := requests["POST {{baseUrl}}/users"]; !ok || create.Body == nil || create.Body.Options.Raw.Language != "json" {
+		t.Errorf("create request = %s, want a JSON body", toJSON(t, create))
+	}
+}
@@ -0,0 +1,111 @@
+package converter
+
+import (
+	"sort"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// ConversionReport is a machine-readable summary of a conversion, for CI
+// dashboards and other tooling
+type ConversionReport struct {
+	TypesByKind           map[string]int    `json:"typesByKind"`
+	OperationsByMethod    map[string]int    `json:"operationsByMethod"`
+	Paths                 int               `json:"paths"`
+	RESTPatterns          int               `json:"restPatterns"`
+	Detections            []DetectionReport `json:"detections"`
+	UnmappedScalars       []string          `json:"unmappedScalars"`
+	UnsupportedDirectives []string          `json:"unsupportedDirectives"` // e.g. "@cacheControl on Query.users", ignored by the converter
+	Warnings              []string          `json:"warnings"`
+}
+
+// ConvertWithReport converts a GraphQL schema like Convert, also returning a
+// summary of what was converted, skipped and warned about
+func (c *Converter) ConvertWithReport(schemaSource string) (*OpenAPIDocument, *ConversionReport, error) {
+	doc, err := c.Convert(schemaSource)
+	if err != nil {
+		return nil, nil, err
+	}
+	return doc, c.Report(), nil
+}
+
+// Report returns the summary of the last Convert call
+func (c *Converter) Report() *ConversionReport {
+	stats := c.Stats()
+	report := &ConversionReport{
+		TypesByKind:           stats.TypesByKind,
+		OperationsByMethod:    stats.OperationsByMethod,
+		RESTPatterns:          stats.RESTPatterns,
+		Detections:            c.detections,
+		UnmappedScalars:       stats.UnmappedScalars,
+		UnsupportedDirectives: []string{},
+		Warnings:              append([]string{}, c.warnings...),
+	}
+	if report.Detections == nil {
+		report.Detections = []DetectionReport{}
+	}
+	if c.doc != nil {
+		report.Paths = len(c.doc.Paths)
+	}
+	if c.schema != nil {
+		report.UnsupportedDirectives = c.unsupportedDirectives()
+	}
+	return report
+}
+
+// unsupportedDirectives lists the directive usages in the schema that no
+// converter feature or Config option reads, as "@name on Type.field"
+func (c *Converter) unsupportedDirectives() []string {
+	understood := map[string]bool{
+		"deprecated": true, "specifiedBy": true, "example": true, "default": true,
+		"rest": true, "httpMethod": true, "resource": true, "response": true, "returns": true, "info": true, "header": true,
+		"externalDocs": true, "optional": true, "public": true,
+		"constraint": true, "oneOf": true, "internal": true,
+		// Apollo Federation: @key picks the resource id, the rest have no REST meaning
+		"key": true, "external": true, "provides": true, "requires": true,
+		"extends": true, "shareable": true, "inaccessible": true, "override": true, "tag": true,
+	}
+	for _, name := range []string{c.config.ConstraintDirectiveName, c.config.OneOfInputDirective, c.config.InternalDirective, c.config.AuthDirective, c.config.HeaderDirectiveName} {
+		understood[name] = true
+	}
+	for name := range constraintAliases {
+		understood[name] = true
+	}
+	for name := range c.config.ConstraintAliases {
+		understood[name] = true
+	}
+	for name := range c.config.DirectiveExtensions {
+		understood[name] = true
+	}
+
+	seen := make(map[string]bool)
+	unsupported := []string{}
+	add := func(directives ast.DirectiveList, location string) {
+		for _, directive := range directives {
+			entry := "@" + directive.Name + " on " + location
+			if understood[directive.Name] || seen[entry] {
+				continue
+			}
+			seen[entry] = true
+			unsupported = append(unsupported, entry)
+		}
+	}
+	add(c.schema.SchemaDirectives, "schema")
+	for _, typeDef := range c.schema.Types {
+		if typeDef.BuiltIn {
+			continue
+		}
+		add(typeDef.Directives, typeDef.Name)
+		for _, field := range typeDef.Fields {
+			add(field.Directives, typeDef.Name+"."+field.Name)
+			for _, arg := range field.Arguments {
+				add(arg.Directives, typeDef.Name+"."+field.Name+"("+arg.Name+")")
+			}
+		}
+		for _, value := range typeDef.EnumValues {
+			add(value.Directives, typeDef.Name+"."+value.Name)
+		}
+	}
+	sort.Strings(unsupported)
+	return unsupported
+}
@@ -0,0 +1,32 @@
+package converter
+
+import (
+	"testing"
+)
+
+func TestConvertWithReport(t *testing.T) {
+	c := New(DefaultConfig())
+	_, report, err := c.ConvertWithReport(`
+directive @cacheControl(maxAge: Int) on FIELD_DEFINITION
+scalar Money
+type User { id: ID!, balance: Money }
+input UserInput { name: String! }
+type Query { users: [User!]! @cacheControl(maxAge: 5), user(id: ID!): User }
+type Mutation { createUser(input: UserInput!): User! }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := toJSON(t, report.OperationsByMethod), `{"get":2,"post":1}`; got != want {
+		t.Errorf("operationsByMethod = %s, want %s", got, want)
+	}
+	if report.Paths != 2 || report.RESTPatterns != 1 || len(report.Detections) != 1 {
+		t.Errorf("paths = %d, restPatterns = %d, detections = %d", report.Paths, report.RESTPatterns, len(report.Detections))
+	}
+	if got, want := toJSON(t, report.UnmappedScalars), `["Money"]`; got != want {
+		t.Errorf("unmappedScalars = %s, want %s", got, want)
+	}
+	if got, want := toJSON(t, report.UnsupportedDirectives), `["@cacheControl on Query.users"]`; got != want {
+		t.Errorf("unsupportedDirectives = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,64 @@
+package converter
+
+import "strings"
+
+// ResourceDocs splits the last Convert result into one standalone document per
+// consolidated REST resource, keyed by its plural (e.g. "users"). Each holds the
+// resource's collection and item paths and the components they reference.
+func (c *Converter) ResourceDocs() map[string]*OpenAPIDocument {
+	docs := make(map[string]*OpenAPIDocument)
+	if c.doc == nil {
+		return docs
+	}
+
+	for _, report := range c.detections {
+		if report.Status != "consolidated" {
+			continue
+		}
+		doc := &OpenAPIDocument{
+			OpenAPI:  c.doc.OpenAPI,
+			Info:     c.doc.Info,
+			Servers:  c.doc.Servers,
+			Paths:    make(map[string]*PathItem),
+			Security: c.doc.Security,
+		}
+		// The collection path and its item path, whatever the item parameter is named
+		base := report.Path
+		for path, item := range c.doc.Paths {
+			param, isItem := strings.CutPrefix(path, base+"/{")
+			if path == base || isItem && strings.HasSuffix(param, "}") && !strings.Contains(param, "/") {
+				doc.Paths[path] = item
+			}
+		}
+		for _, tag := range c.doc.Tags {
+			if tag.Name == report.Plural {
+				doc.Tags = append(doc.Tags, tag)
+			}
+		}
+
+		doc.Components = &Components{
+			Schemas:         make(map[string]*Schema),
+			SecuritySchemes: c.doc.Components.SecuritySchemes,
+		}
+		for ref := range usedComponents(&OpenAPIDocument{Paths: doc.Paths, Components: c.doc.Components}) {
+			if name, ok := strings.CutPrefix(ref, "schemas/"); ok && c.doc.Components.Schemas[name] != nil {
+				doc.Components.Schemas[name] = c.doc.Components.Schemas[name]
+			}
+			if name, ok := strings.CutPrefix(ref, "parameters/"); ok && c.doc.Components.Parameters[name] != nil {
+				if doc.Components.Parameters == nil {
+					doc.Components.Parameters = make(map[string]*Parameter)
+				}
+				doc.Components.Parameters[name] = c.doc.Components.Parameters[name]
+			}
+			if name, ok := strings.CutPrefix(ref, "responses/"); ok && c.doc.Components.Responses[name] != nil {
+				if doc.Components.Responses == nil {
+					doc.Components.Responses = make(map[string]*Response)
+				}
+				doc.Components.Responses[name] = c.doc.Components.Responses[name]
+			}
+		}
+
+		docs[report.Plural] = doc
+	}
+	return docs
+}
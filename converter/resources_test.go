@@ -0,0 +1,41 @@
+package converter
+
+import (
+	"testing"
+)
+
+func TestResourceDocs(t *testing.T) {
+	c := New(DefaultConfig())
+	if _, err := c.Convert(`
+type User { id: ID!, posts: [Post!]! }
+type Post { id: ID!, title: String! }
+input UserInput { name: String! }
+type Query { users: [User!]!, user(id: ID!): User, stats: Int }
+type Mutation { createUser(input: UserInput!): User! }
+`); err != nil {
+		t.Fatal(err)
+	}
+	docs := c.ResourceDocs()
+	users := docs["users"]
+	if len(docs) != 1 || users == nil {
+		t.Fatalf("ResourceDocs = %v, want only users", docs)
+	}
+	paths := []string{}
+	for _, path := range sortedPaths(users) {
+		paths = append(paths, path)
+	}
+	if got, want := toJSON(t, paths), `["/users","/users/{id}"]`; got != want {
+		t.Errorf("paths = %s, want %s", got, want)
+	}
+	for _, name := range []string{"User", "UserInput"} {
+		if users.Components.Schemas[name] == nil {
+			t.Errorf("missing referenced component %s", name)
+		}
+	}
+	if users.Components.Schemas["Post"] != nil {
+		t.Error("Post is not referenced by the users paths")
+	}
+	if errs := Validate(users); len(errs) != 0 {
+		t.Errorf("resource document is invalid: %v", errs)
+	}
+}
@@ -0,0 +1,55 @@
+package converter
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Stats summarizes a conversion
+type Stats struct {
+	TypesByKind        map[string]int `json:"typesByKind"`        // GraphQL types by kind (object, input_object, enum, ...)
+	OperationsByMethod map[string]int `json:"operationsByMethod"` // Generated operations by HTTP method
+	RESTPatterns       int            `json:"restPatterns"`       // Resources consolidated into REST endpoints
+	Warnings           int            `json:"warnings"`
+	UnmappedScalars    []string       `json:"unmappedScalars"` // Custom scalars emitted as plain strings (no @specifiedBy or mapping)
+}
+
+// Stats returns metrics about the last Convert call
+func (c *Converter) Stats() Stats {
+	stats := Stats{
+		TypesByKind:        make(map[string]int),
+		OperationsByMethod: make(map[string]int),
+		Warnings:           len(c.warnings),
+		UnmappedScalars:    []string{},
+	}
+	if c.schema == nil || c.doc == nil {
+		return stats
+	}
+
+	for _, typeDef := range c.schema.Types {
+		if isBuiltInType(typeDef.Name) || isScalarType(typeDef.Name) {
+			continue
+		}
+		stats.TypesByKind[strings.ToLower(string(typeDef.Kind))]++
+		if typeDef.Kind == ast.Scalar && typeDef.Directives.ForName("specifiedBy") == nil &&
+			c.mapScalarSchema(typeDef.Name) == nil && c.scalarSchema(typeDef.Name) == nil {
+			stats.UnmappedScalars = append(stats.UnmappedScalars, typeDef.Name)
+		}
+	}
+	sort.Strings(stats.UnmappedScalars)
+
+	for _, item := range c.doc.Paths {
+		for _, po := range pathOperations(item) {
+			stats.OperationsByMethod[po.Method]++
+		}
+	}
+
+	for _, report := range c.detections {
+		if report.Status == "consolidated" {
+			stats.RESTPatterns++
+		}
+	}
+	return stats
+}
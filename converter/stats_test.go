@@ -0,0 +1,24 @@
+package converter
+
+import (
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	c := New(DefaultConfig())
+	if _, err := c.Convert(`
+scalar Money
+enum Role { ADMIN }
+type User { id: ID!, role: Role, balance: Money }
+input UserInput { name: String! }
+type Query { users: [User!]!, user(id: ID!): User }
+type Mutation { createUser(input: UserInput!): User! }
+`); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"typesByKind":{"enum":1,"input_object":1,"object":1,"scalar":1},"operationsByMethod":{"get":2,"post":1},` +
+		`"restPatterns":1,"warnings":0,"unmappedScalars":["Money"]}`
+	if got := toJSON(t, c.Stats()); got != want {
+		t.Errorf("Stats = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,158 @@
+package converter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConvertStream converts a GraphQL schema like Convert and writes the document
+// to w as "yaml" or "json", byte for byte what marshaling Convert's result
+// (with MarshalYAML or json.MarshalIndent(doc, "", "  ")) would produce.
+//
+// The paths section, which dominates large schemas, is emitted incrementally:
+// each path item gets its examples and content types just before it is
+// written and is dropped from the document once written, so neither the
+// finished paths section nor its serialized form is ever held whole. (The
+// operations are still all generated before the first path is written, since
+// queries and mutations can share a path.) Nothing is written if the schema
+// fails to convert. Afterwards the converter's document has no paths left,
+// so use Convert when Stats, Report or the document itself are needed.
+func (c *Converter) ConvertStream(schemaSource string, w io.Writer, format string) error {
+	var stream func(*OpenAPIDocument, io.Writer, func(string) *PathItem) error
+	switch format {
+	case "yaml", "":
+		stream = streamYAML
+	case "json":
+		stream = streamJSON
+	default:
+		return fmt.Errorf("unsupported stream format %q (expected yaml or json)", format)
+	}
+	if err := c.build(schemaSource); err != nil {
+		return err
+	}
+
+	out := bufio.NewWriter(w)
+	next := func(path string) *PathItem {
+		item := c.doc.Paths[path]
+		c.finishPathItem(item)
+		delete(c.doc.Paths, path)
+		return item
+	}
+	if err := stream(c.doc, out, next); err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+// withoutPaths returns the document skeleton, with an empty paths section
+// standing in for the one the stream writers fill in
+func withoutPaths(doc *OpenAPIDocument) *OpenAPIDocument {
+	skeleton := *doc
+	skeleton.Paths = map[string]*PathItem{}
+	return &skeleton
+}
+
+// streamYAML writes doc as YAML, taking each path item from next as it reaches it
+func streamYAML(doc *OpenAPIDocument, w io.Writer, next func(path string) *PathItem) error {
+	skeleton, err := yaml.Marshal(withoutPaths(doc))
+	if err != nil {
+		return err
+	}
+	before, after, ok := bytes.Cut(skeleton, []byte("\npaths: {}\n"))
+	if !ok {
+		return fmt.Errorf("streaming yaml: paths section not found")
+	}
+	if _, err := fmt.Fprintf(w, "%s\npaths:", before); err != nil {
+		return err
+	}
+	if len(doc.Paths) == 0 {
+		if _, err := io.WriteString(w, " {}"); err != nil {
+			return err
+		}
+	}
+	// yaml.v3 orders map keys its own way (numbers by value), so ask it
+	keys := make(map[string]bool, len(doc.Paths))
+	for path := range doc.Paths {
+		keys[path] = true
+	}
+	var order yaml.Node
+	if err := order.Encode(keys); err != nil {
+		return err
+	}
+	for i := 0; i < len(order.Content); i += 2 {
+		path := order.Content[i].Value
+		item, err := yaml.Marshal(map[string]*PathItem{path: next(path)})
+		if err != nil {
+			return err
+		}
+		for _, line := range bytes.SplitAfter(item, []byte("\n")) {
+			if len(line) == 0 {
+				continue
+			}
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+			if len(line) > 1 {
+				if _, err := io.WriteString(w, "    "); err != nil {
+					return err
+				}
+			}
+			if _, err := w.Write(bytes.TrimSuffix(line, []byte("\n"))); err != nil {
+				return err
+			}
+		}
+	}
+	_, err = fmt.Fprintf(w, "\n%s", after)
+	return err
+}
+
+// streamJSON writes doc as indented JSON, taking each path item from next as
+// it reaches it
+func streamJSON(doc *OpenAPIDocument, w io.Writer, next func(path string) *PathItem) error {
+	skeleton, err := json.MarshalIndent(withoutPaths(doc), "", "  ")
+	if err != nil {
+		return err
+	}
+	before, after, ok := bytes.Cut(skeleton, []byte("\n  \"paths\": {}"))
+	if !ok {
+		return fmt.Errorf("streaming json: paths section not found")
+	}
+	if _, err := fmt.Fprintf(w, "%s\n  \"paths\": {", before); err != nil {
+		return err
+	}
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths) // encoding/json's map key order
+	for i, path := range paths {
+		key, err := json.Marshal(path)
+		if err != nil {
+			return err
+		}
+		item, err := json.MarshalIndent(next(path), "    ", "  ")
+		if err != nil {
+			return err
+		}
+		separator := ","
+		if i == 0 {
+			separator = ""
+		}
+		if _, err := fmt.Fprintf(w, "%s\n    %s: %s", separator, key, item); err != nil {
+			return err
+		}
+	}
+	if len(paths) > 0 {
+		if _, err := io.WriteString(w, "\n  "); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "}%s", after)
+	return err
+}
@@ -0,0 +1,117 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+// largeSchema generates n CRUD resources, enough for the paths section to
+// dominate the document
+func largeSchema(n int) string {
+	var sdl strings.Builder
+	var query, mutation strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&sdl, "type Item%d { id: ID! name: String! count: Int tags: [String!] }\n", i)
+		fmt.Fprintf(&sdl, "input Item%dInput { name: String! count: Int }\n", i)
+		fmt.Fprintf(&query, "  item%d(id: ID!): Item%d\n  item%ds: [Item%d!]!\n", i, i, i, i)
+		fmt.Fprintf(&mutation, "  createItem%d(input: Item%dInput!): Item%d!\n", i, i, i)
+		fmt.Fprintf(&mutation, "  updateItem%d(id: ID!, input: Item%dInput!): Item%d!\n", i, i, i)
+		fmt.Fprintf(&mutation, "  deleteItem%d(id: ID!): Boolean!\n", i)
+	}
+	fmt.Fprintf(&sdl, "type Query {\n%s}\ntype Mutation {\n%s}\n", query.String(), mutation.String())
+	return sdl.String()
+}
+
+func TestConvertStreamMatchesConvert(t *testing.T) {
+	examples := DefaultConfig()
+	examples.MinimalRequestExamples = true
+	examples.ResponseContentTypes = []string{"application/json", "application/xml"}
+	examples.OpenAPIVersion = "3.1.0"
+	noPaths := DefaultConfig()
+	noPaths.ExcludeOperations = []string{"*"}
+	tests := []struct {
+		name   string
+		config Config
+		sdl    string
+	}{
+		{"large", DefaultConfig(), largeSchema(50)},
+		{"examples and content types", examples, `
+directive @example(value: String) on FIELD_DEFINITION
+type Item { id: ID!, name: String! @example(value: "widget") }
+input ItemInput { name: String! }
+type Query { items: [Item!]!, item(id: ID!): Item }
+type Mutation { createItem(input: ItemInput!): Item! }
+type Subscription { onItem: Item! }
+`},
+		{"no paths", noPaths, "type Item { id: ID! }\ntype Query { item: Item }"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, format := range []string{"yaml", "json"} {
+				doc, err := New(tt.config).Convert(tt.sdl)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var buffered []byte
+				if format == "json" {
+					buffered, err = json.MarshalIndent(doc, "", "  ")
+				} else {
+					buffered, err = MarshalYAML(doc)
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				c := New(tt.config)
+				var streamed bytes.Buffer
+				if err := c.ConvertStream(tt.sdl, &streamed, format); err != nil {
+					t.Fatal(err)
+				}
+				if streamed.String() != string(buffered) {
+					t.Errorf("streamed %s differs from Convert (%d paths):\n%s", format, len(doc.Paths), lineDiff(string(buffered), streamed.String()))
+				}
+				if c.Report().Paths != 0 {
+					t.Errorf("%s: %d paths left after streaming, want them released", format, c.Report().Paths)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertStreamErrors(t *testing.T) {
+	var out bytes.Buffer
+	if err := New(DefaultConfig()).ConvertStream("type Query {", &out, "yaml"); err == nil || out.Len() != 0 {
+		t.Errorf("invalid schema: err = %v, wrote %q; want an error and no output", err, out.String())
+	}
+	if err := New(DefaultConfig()).ConvertStream("type Query { hello: String }", &out, "xml"); err == nil || out.Len() != 0 {
+		t.Errorf("unknown format: err = %v, wrote %q; want an error and no output", err, out.String())
+	}
+}
+
+func BenchmarkConvertStream(b *testing.B) {
+	sdl := largeSchema(500)
+	b.Run("buffered", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			doc, err := New(DefaultConfig()).Convert(sdl)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if _, err := MarshalYAML(doc); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("streamed", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := New(DefaultConfig()).ConvertStream(sdl, io.Discard, "yaml"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
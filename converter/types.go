@@ -2,56 +2,125 @@ package converter
 
 // OpenAPIDocument represents an OpenAPI 3.0 document
 type OpenAPIDocument struct {
-	OpenAPI    string                `json:"openapi" yaml:"openapi"`
-	Info       Info                  `json:"info" yaml:"info"`
-	Servers    []Server              `json:"servers,omitempty" yaml:"servers,omitempty"`
-	Paths      map[string]*PathItem  `json:"paths" yaml:"paths"`
-	Components *Components           `json:"components,omitempty" yaml:"components,omitempty"`
+	OpenAPI      string                 `json:"openapi" yaml:"openapi"`
+	Info         Info                   `json:"info" yaml:"info"`
+	Servers      []Server               `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Tags         []Tag                  `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Paths        map[string]*PathItem   `json:"paths" yaml:"paths"`
+	Webhooks     map[string]*PathItem   `json:"webhooks,omitempty" yaml:"webhooks,omitempty"` // OpenAPI 3.1 only
+	Components   *Components            `json:"components,omitempty" yaml:"components,omitempty"`
+	TagGroups    []TagGroup             `json:"x-tagGroups,omitempty" yaml:"x-tagGroups,omitempty"`
+	GeneratedBy  string                 `json:"x-generated-by,omitempty" yaml:"x-generated-by,omitempty"`
+	Security     SecurityRequirements   `json:"security,omitzero" yaml:"security,omitempty"`
+	ExternalDocs *ExternalDocumentation `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+}
+
+// ExternalDocumentation links to documentation outside the spec
+type ExternalDocumentation struct {
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	URL         string `json:"url" yaml:"url"`
+}
+
+// SecurityRequirements lists alternative security requirements. A non-nil
+// empty list is still emitted (security: []), which makes an operation public
+// when the document declares a default requirement.
+type SecurityRequirements []map[string][]string
+
+// IsZero reports whether the requirements are unset, for omitempty/omitzero
+func (s SecurityRequirements) IsZero() bool {
+	return s == nil
+}
+
+// Tag adds metadata to a tag used by operations
+type Tag struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// TagGroup groups tags into a navigation section (Redoc x-tagGroups extension)
+type TagGroup struct {
+	Name string   `json:"name" yaml:"name"`
+	Tags []string `json:"tags" yaml:"tags"`
 }
 
 // Info contains API metadata
 type Info struct {
-	Title       string `json:"title" yaml:"title"`
-	Description string `json:"description,omitempty" yaml:"description,omitempty"`
-	Version     string `json:"version" yaml:"version"`
+	Title          string   `json:"title" yaml:"title"`
+	Description    string   `json:"description,omitempty" yaml:"description,omitempty"`
+	TermsOfService string   `json:"termsOfService,omitempty" yaml:"termsOfService,omitempty"`
+	Contact        *Contact `json:"contact,omitempty" yaml:"contact,omitempty"`
+	License        *License `json:"license,omitempty" yaml:"license,omitempty"`
+	Version        string   `json:"version" yaml:"version"`
+}
+
+// Contact contains contact information for the API
+type Contact struct {
+	Name  string `json:"name,omitempty" yaml:"name,omitempty"`
+	URL   string `json:"url,omitempty" yaml:"url,omitempty"`
+	Email string `json:"email,omitempty" yaml:"email,omitempty"`
+}
+
+// License contains license information for the API
+type License struct {
+	Name string `json:"name" yaml:"name"`
+	URL  string `json:"url,omitempty" yaml:"url,omitempty"`
 }
 
 // Server represents an API server
 type Server struct {
-	URL         string `json:"url" yaml:"url"`
-	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	URL         string                     `json:"url" yaml:"url"`
+	Description string                     `json:"description,omitempty" yaml:"description,omitempty"`
+	Variables   map[string]*ServerVariable `json:"variables,omitempty" yaml:"variables,omitempty"` // Values for {name} placeholders in URL
+}
+
+// ServerVariable is a templated part of a server URL, e.g. {region}
+type ServerVariable struct {
+	Default     string   `json:"default" yaml:"default"`
+	Enum        []string `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
 }
 
 // PathItem describes operations available on a path
 type PathItem struct {
-	Get     *Operation `json:"get,omitempty" yaml:"get,omitempty"`
-	Post    *Operation `json:"post,omitempty" yaml:"post,omitempty"`
-	Put     *Operation `json:"put,omitempty" yaml:"put,omitempty"`
-	Delete  *Operation `json:"delete,omitempty" yaml:"delete,omitempty"`
-	Patch   *Operation `json:"patch,omitempty" yaml:"patch,omitempty"`
-	Options *Operation `json:"options,omitempty" yaml:"options,omitempty"`
+	// Parameters shared by every operation on the path, e.g. an item's {id}
+	Parameters []*Parameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Get        *Operation   `json:"get,omitempty" yaml:"get,omitempty"`
+	Post       *Operation   `json:"post,omitempty" yaml:"post,omitempty"`
+	Put        *Operation   `json:"put,omitempty" yaml:"put,omitempty"`
+	Delete     *Operation   `json:"delete,omitempty" yaml:"delete,omitempty"`
+	Patch      *Operation   `json:"patch,omitempty" yaml:"patch,omitempty"`
+	Options    *Operation   `json:"options,omitempty" yaml:"options,omitempty"`
+	Head       *Operation   `json:"head,omitempty" yaml:"head,omitempty"`
 }
 
 // Operation describes a single API operation
 type Operation struct {
-	OperationID string              `json:"operationId,omitempty" yaml:"operationId,omitempty"`
-	Summary     string              `json:"summary,omitempty" yaml:"summary,omitempty"`
-	Description string              `json:"description,omitempty" yaml:"description,omitempty"`
-	Parameters  []*Parameter        `json:"parameters,omitempty" yaml:"parameters,omitempty"`
-	RequestBody *RequestBody        `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
-	Responses   map[string]*Response `json:"responses" yaml:"responses"`
-	Deprecated  bool                `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Tags         []string               `json:"tags,omitempty" yaml:"tags,omitempty"`
+	OperationID  string                 `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	Summary      string                 `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description  string                 `json:"description,omitempty" yaml:"description,omitempty"`
+	Parameters   []*Parameter           `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody  *RequestBody           `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses    map[string]*Response   `json:"responses" yaml:"responses"`
+	Deprecated   bool                   `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Security     SecurityRequirements   `json:"security,omitzero" yaml:"security,omitempty"`
+	ExternalDocs *ExternalDocumentation `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+	// Vendor extensions (x-*), emitted inline alongside the fields above
+	Extensions map[string]interface{} `json:"-" yaml:",inline"`
 }
 
 // Parameter describes a single operation parameter
 type Parameter struct {
-	Name        string  `json:"name" yaml:"name"`
-	In          string  `json:"in" yaml:"in"` // query, path, header, cookie
-	Description string  `json:"description,omitempty" yaml:"description,omitempty"`
-	Required    bool    `json:"required,omitempty" yaml:"required,omitempty"`
-	Schema      *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
-	Style       string  `json:"style,omitempty" yaml:"style,omitempty"`
-	Explode     bool    `json:"explode,omitempty" yaml:"explode,omitempty"`
+	Ref         string      `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Name        string      `json:"name,omitempty" yaml:"name,omitempty"`
+	In          string      `json:"in,omitempty" yaml:"in,omitempty"` // query, path, header, cookie
+	Description string      `json:"description,omitempty" yaml:"description,omitempty"`
+	Required    bool        `json:"required,omitempty" yaml:"required,omitempty"`
+	Deprecated  bool        `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Schema      *Schema     `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Style       string      `json:"style,omitempty" yaml:"style,omitempty"`
+	Explode     *bool       `json:"explode,omitempty" yaml:"explode,omitempty"`
+	Example     interface{} `json:"example,omitempty" yaml:"example,omitempty"`
 }
 
 // RequestBody describes a request body
@@ -63,35 +132,104 @@ type RequestBody struct {
 
 // Response describes a single response
 type Response struct {
-	Description string                `json:"description" yaml:"description"`
-	Content     map[string]*MediaType `json:"content,omitempty" yaml:"content,omitempty"`
+	Ref         string             `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Description string             `json:"description,omitempty" yaml:"description,omitempty"`
+	Headers     map[string]*Header `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Content     Content            `json:"content,omitzero" yaml:"content,omitempty"`
+}
+
+// Header describes a response header
+type Header struct {
+	Description string  `json:"description,omitempty" yaml:"description,omitempty"`
+	Schema      *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+// Content maps media types to their schemas. A non-nil empty map is still
+// emitted (content: {}) for gateways that require it on empty responses.
+type Content map[string]*MediaType
+
+// IsZero reports whether the content is unset, for omitempty/omitzero
+func (c Content) IsZero() bool {
+	return c == nil
 }
 
 // MediaType describes a media type
 type MediaType struct {
-	Schema *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Schema  *Schema     `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Example interface{} `json:"example,omitempty" yaml:"example,omitempty"`
 }
 
 // Components holds reusable objects
 type Components struct {
-	Schemas map[string]*Schema `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+	Schemas         map[string]*Schema         `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+	Parameters      map[string]*Parameter      `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Responses       map[string]*Response       `json:"responses,omitempty" yaml:"responses,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty" yaml:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme defines a security scheme that can be used by operations
+type SecurityScheme struct {
+	Type         string `json:"type" yaml:"type"` // http, apiKey, oauth2, openIdConnect
+	Description  string `json:"description,omitempty" yaml:"description,omitempty"`
+	Scheme       string `json:"scheme,omitempty" yaml:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty" yaml:"bearerFormat,omitempty"`
+	In           string `json:"in,omitempty" yaml:"in,omitempty"`
+	Name         string `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+// EnumValueMetadata documents one enum value, aligned with Schema.Enum
+type EnumValueMetadata struct {
+	Value             string `json:"value" yaml:"value"`
+	Description       string `json:"description,omitempty" yaml:"description,omitempty"`
+	Deprecated        bool   `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	DeprecationReason string `json:"deprecationReason,omitempty" yaml:"deprecationReason,omitempty"`
+}
+
+// Discriminator names the property that tells oneOf branches apart
+type Discriminator struct {
+	PropertyName string            `json:"propertyName" yaml:"propertyName"`
+	Mapping      map[string]string `json:"mapping,omitempty" yaml:"mapping,omitempty"`
 }
 
 // Schema describes a data type
 type Schema struct {
+	Title       string             `json:"title,omitempty" yaml:"title,omitempty"`
 	Type        string             `json:"type,omitempty" yaml:"type,omitempty"`
 	Format      string             `json:"format,omitempty" yaml:"format,omitempty"`
 	Description string             `json:"description,omitempty" yaml:"description,omitempty"`
 	Properties  map[string]*Schema `json:"properties,omitempty" yaml:"properties,omitempty"`
 	Required    []string           `json:"required,omitempty" yaml:"required,omitempty"`
-	Items       *Schema            `json:"items,omitempty" yaml:"items,omitempty"`
-	Ref         string             `json:"$ref,omitempty" yaml:"$ref,omitempty"`
-	Deprecated  bool               `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
-	Enum        []string           `json:"enum,omitempty" yaml:"enum,omitempty"`
-	OneOf       []*Schema          `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
-	MinLength   *int               `json:"minLength,omitempty" yaml:"minLength,omitempty"`
-	MaxLength   *int               `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
-	Minimum     *float64           `json:"minimum,omitempty" yaml:"minimum,omitempty"`
-	Maximum     *float64           `json:"maximum,omitempty" yaml:"maximum,omitempty"`
-	Pattern     string             `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	// false, true or a *Schema for the values of undeclared properties
+	AdditionalProperties interface{}         `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+	Items                *Schema             `json:"items,omitempty" yaml:"items,omitempty"`
+	Ref                  string              `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Deprecated           bool                `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Nullable             bool                `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+	Enum                 []string            `json:"enum,omitempty" yaml:"enum,omitempty"`
+	EnumMetadata         []EnumValueMetadata `json:"x-enum-metadata,omitempty" yaml:"x-enum-metadata,omitempty"`
+	OneOf                []*Schema           `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
+	AllOf                []*Schema           `json:"allOf,omitempty" yaml:"allOf,omitempty"`
+	Discriminator        *Discriminator      `json:"discriminator,omitempty" yaml:"discriminator,omitempty"`
+	MinLength            *int                `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	MaxLength            *int                `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	Minimum              *float64            `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	Maximum              *float64            `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	// Exclusive bounds are a number in OpenAPI 3.1, but in 3.0 a flag (true)
+	// making the sibling minimum/maximum exclusive
+	ExclusiveMinimum interface{} `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum interface{} `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
+	MultipleOf       *float64    `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
+	Pattern          string      `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	MinItems         *int        `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+	MaxItems         *int        `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+	UniqueItems      bool        `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
+	Default          interface{} `json:"default,omitempty" yaml:"default,omitempty"`
+	Example          interface{} `json:"example,omitempty" yaml:"example,omitempty"`
+	ErrorMessage     string      `json:"x-errorMessage,omitempty" yaml:"x-errorMessage,omitempty"`
+	ReadOnly         bool        `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`
+	WriteOnly        bool        `json:"writeOnly,omitempty" yaml:"writeOnly,omitempty"`
+	// Emit type as [Type, "null"], OpenAPI 3.1's spelling of nullable
+	NullableType bool `json:"-" yaml:"-"`
+	// Vendor extensions (x-*), emitted inline alongside the fields above
+	Extensions map[string]interface{} `json:"-" yaml:",inline"`
 }
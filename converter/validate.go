@@ -0,0 +1,105 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// anyRef matches every $ref target in a marshaled document
+var anyRef = regexp.MustCompile(`"\$ref":"([^"]+)"`)
+
+// serverVariable matches the {name} placeholders of a server URL
+var serverVariable = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// Validate checks a document for structural problems: $refs that do not
+// resolve to a component, paths without operations, operations without
+// responses, duplicate operationIds and server URL variables left undefined or
+// defaulting outside their enum. Unlike Lint, any finding makes the
+// document invalid.
+func Validate(doc *OpenAPIDocument) []error {
+	errs := []error{}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return append(errs, fmt.Errorf("document cannot be marshaled: %w", err))
+	}
+	unresolved := make(map[string]bool)
+	for _, match := range anyRef.FindAllStringSubmatch(string(data), -1) {
+		if !resolves(doc, match[1]) {
+			unresolved[match[1]] = true
+		}
+	}
+	refs := make([]string, 0, len(unresolved))
+	for ref := range unresolved {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	for _, ref := range refs {
+		errs = append(errs, fmt.Errorf("$ref %s does not resolve", ref))
+	}
+
+	for _, server := range doc.Servers {
+		for _, match := range serverVariable.FindAllStringSubmatch(server.URL, -1) {
+			if server.Variables[match[1]] == nil {
+				errs = append(errs, fmt.Errorf("server %s has no variable %q", server.URL, match[1]))
+			}
+		}
+		names := make([]string, 0, len(server.Variables))
+		for name := range server.Variables {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			variable := server.Variables[name]
+			if len(variable.Enum) > 0 && !slices.Contains(variable.Enum, variable.Default) {
+				errs = append(errs, fmt.Errorf("server %s variable %q defaults to %q, outside its enum", server.URL, name, variable.Default))
+			}
+		}
+	}
+
+	operationIDs := make(map[string]string)
+	for _, path := range sortedPaths(doc) {
+		ops := pathOperations(doc.Paths[path])
+		if len(ops) == 0 {
+			errs = append(errs, fmt.Errorf("path %s has no operations", path))
+		}
+		for _, po := range ops {
+			location := strings.ToUpper(po.Method) + " " + path
+			if len(po.Operation.Responses) == 0 {
+				errs = append(errs, fmt.Errorf("%s has no responses", location))
+			}
+			id := po.Operation.OperationID
+			if id == "" {
+				continue
+			}
+			if first, ok := operationIDs[id]; ok {
+				errs = append(errs, fmt.Errorf("%s reuses operationId %q of %s", location, id, first))
+				continue
+			}
+			operationIDs[id] = location
+		}
+	}
+
+	return errs
+}
+
+// resolves reports whether ref points at a component defined in doc
+func resolves(doc *OpenAPIDocument, ref string) bool {
+	if doc.Components == nil {
+		return false
+	}
+	if name, ok := strings.CutPrefix(ref, "#/components/schemas/"); ok {
+		return doc.Components.Schemas[name] != nil
+	}
+	if name, ok := strings.CutPrefix(ref, "#/components/parameters/"); ok {
+		return doc.Components.Parameters[name] != nil
+	}
+	if name, ok := strings.CutPrefix(ref, "#/components/responses/"); ok {
+		return doc.Components.Responses[name] != nil
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	if errs := Validate(convertSDL(t, DefaultConfig(), `type User { id: ID! }
+type Query { user(id: ID!): User }`)); len(errs) != 0 {
+		t.Errorf("a converted document should be valid, got %v", errs)
+	}
+
+	doc := &OpenAPIDocument{
+		Paths: map[string]*PathItem{
+			"/a":     {Get: &Operation{OperationID: "same", Responses: map[string]*Response{"200": {Description: "OK", Content: map[string]*MediaType{"application/json": {Schema: &Schema{Ref: "#/components/schemas/Missing"}}}}}}},
+			"/b":     {Get: &Operation{OperationID: "same"}},
+			"/empty": {},
+		},
+		Components: &Components{Schemas: map[string]*Schema{}},
+		Servers:    []Server{{URL: "https://{region}.example.com"}},
+	}
+	got := []string{}
+	for _, err := range Validate(doc) {
+		got = append(got, err.Error())
+	}
+	if len(got) != 5 {
+		t.Errorf("Validate found %d problems, want 5 (unresolved $ref, empty path, no responses, duplicate operationId, undefined server variable):\n%s", len(got), toJSON(t, got))
+	}
+}
+
+func TestValidateServerVariables(t *testing.T) {
+	variables := map[string]*ServerVariable{
+		"region":  {Default: "us", Enum: []string{"us", "eu"}},
+		"version": {Default: "v3", Enum: []string{"v1", "v2"}},
+	}
+	doc := convertSDL(t, DefaultConfig(), `type Query { hello: String }`)
+	doc.Servers = []Server{{URL: "https://{region}.api.example.com/{version}", Variables: variables}}
+	errs := Validate(doc)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), `variable "version" defaults to "v3", outside its enum`) {
+		t.Errorf("Validate = %v, want the version default reported", errs)
+	}
+
+	data, err := MarshalYAML(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "url: https://{region}.api.example.com/{version}\n      variables:\n") {
+		t.Errorf("servers not serialized with their variables:\n%s", data)
+	}
+}
@@ -3,7 +3,8 @@ module github.com/choonkeat/graphql-to-openapi
 go 1.25.3
 
 require (
-	github.com/agnivade/levenshtein v1.2.1 // indirect
-	github.com/vektah/gqlparser/v2 v2.5.31 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
+	github.com/vektah/gqlparser/v2 v2.5.31
+	gopkg.in/yaml.v3 v3.0.1
 )
+
+require github.com/agnivade/levenshtein v1.2.1 // indirect
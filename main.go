@@ -1,32 +1,104 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/choonkeat/graphql-to-openapi/converter"
 )
 
+// stringList is a repeatable flag that also accepts comma-separated values
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	for _, s := range strings.Split(value, ",") {
+		if trimmed := strings.TrimSpace(s); trimmed != "" {
+			*l = append(*l, trimmed)
+		}
+	}
+	return nil
+}
+
+// repeatedList is a repeatable flag taking each value whole, for free text
+// that may itself contain commas
+type repeatedList []string
+
+func (l *repeatedList) String() string {
+	return strings.Join(*l, ", ")
+}
+
+func (l *repeatedList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
 func main() {
+	var baseURLs stringList
+	var serverDescriptions repeatedList
+	flag.Var(&baseURLs, "base-url", "Base URL for the API (repeatable or comma-separated)")
+	flag.Var(&serverDescriptions, "server-description", "Description for each -base-url, in order (repeat the flag for each)")
+
 	var (
-		schemaFile          = flag.String("schema", "", "GraphQL schema file (required)")
-		outputFile          = flag.String("output", "openapi.yaml", "Output OpenAPI file")
-		format              = flag.String("format", "yaml", "Output format: yaml or json")
-		title               = flag.String("title", "Converted from GraphQL", "API title")
-		version             = flag.String("version", "1.0.0", "API version")
-		baseURL             = flag.String("base-url", "", "Base URL for the API")
-		pathPrefix          = flag.String("path-prefix", "", "Path prefix for all endpoints (e.g., \"/api/v1\")")
-		detectRESTPatterns  = flag.Bool("detect-rest-patterns", true, "Enable REST pattern detection")
-		pluralizeSuffixes   = flag.String("pluralize-suffixes", "", "Custom pluralization suffix rules as JSON file")
+		schemaFile         = flag.String("schema", "", "GraphQL schema file (required)")
+		configFile         = flag.String("config", "", "YAML or JSON file of converter settings; flags given explicitly override it")
+		schemaFormat       = flag.String("schema-format", "sdl", "Schema file format: sdl or introspection (JSON result of an introspection query)")
+		outputFile         = flag.String("output", "openapi.yaml", "Output OpenAPI file, or - for stdout")
+		format             = flag.String("format", "yaml", "Output format: yaml, json, postman (v2.1 collection) or jsonschema (component schemas only)")
+		intFormat          = flag.String("int-format", "int32", "Format for GraphQL Int: int32 or int64")
+		idFormat           = flag.String("id-format", "", "Shape of GraphQL ID values: uuid or int64 (default plain string)")
+		strictObjects      = flag.Bool("strict-objects", false, "Set additionalProperties: false on object schemas and request bodies")
+		title              = flag.String("title", "Converted from GraphQL", "API title")
+		version            = flag.String("version", "1.0.0", "API version")
+		pathPrefix         = flag.String("path-prefix", "", "Path prefix for all endpoints (e.g., \"/api/v1\")")
+		pathCase           = flag.String("path-case", "camel", "Case of path segments derived from field names: camel, kebab or snake")
+		prefixInServerURL  = flag.Bool("prefix-in-server-url", false, "Append -path-prefix to the server URLs instead of the path keys")
+		noFooter           = flag.Bool("no-footer", false, "Leave the \"Converted from GraphQL\" footer out of info.description")
+		contactName        = flag.String("contact-name", "", "API contact name")
+		contactEmail       = flag.String("contact-email", "", "API contact email")
+		contactURL         = flag.String("contact-url", "", "API contact URL")
+		licenseName        = flag.String("license-name", "", "API license name (e.g., \"MIT\")")
+		licenseURL         = flag.String("license-url", "", "API license URL")
+		termsURL           = flag.String("terms-url", "", "API terms of service URL")
+		externalDocsURL    = flag.String("external-docs-url", "", "URL of external API documentation (externalDocs)")
+		externalDocsDesc   = flag.String("external-docs-description", "", "Description of the -external-docs-url link")
+		openAPIVersion     = flag.String("openapi-version", "3.0.0", "OpenAPI version to emit: 3.0.0 or 3.1.0")
+		detectRESTPatterns = flag.Bool("detect-rest-patterns", true, "Enable REST pattern detection")
+		graphQLEndpoint    = flag.Bool("include-graphql-endpoint", false, "Also document a POST /graphql endpoint taking {query, variables, operationName}")
+		disableREST        = flag.String("disable-rest-resources", "", "Comma-separated resources to exclude from REST consolidation (e.g., \"user,post\")")
+		includeTypes       = flag.String("include-types", "", "Comma-separated types to convert, by name or glob (default: all)")
+		excludeTypes       = flag.String("exclude-types", "", "Comma-separated types to leave out, by name or glob (e.g., \"Internal*\")")
+		includeOperations  = flag.String("include-operations", "", "Comma-separated query/mutation/subscription fields to convert (default: all)")
+		excludeOperations  = flag.String("exclude-operations", "", "Comma-separated query/mutation/subscription fields to leave out (e.g., \"admin*\")")
+		detectionReport    = flag.Bool("detection-report", false, "Print REST pattern detection decisions without writing output")
+		stats              = flag.Bool("stats", false, "Print a conversion summary (types, operations, REST patterns, warnings) to stderr")
+		report             = flag.String("report", "", "Write a JSON conversion report (counts, detections, unsupported directives, warnings) to this file")
+		validate           = flag.Bool("validate", false, "Check the generated spec for structural errors, exiting non-zero if any are found")
+		lint               = flag.Bool("lint", false, "Print style warnings about the generated spec")
+		watch              = flag.Bool("watch", false, "Regenerate the output whenever the schema file changes, until interrupted")
+		quiet              = flag.Bool("quiet", false, "Suppress progress messages; warnings and errors are still printed")
+		dryRun             = flag.Bool("dry-run", false, "Compare the output with the existing -output file instead of writing it, exiting non-zero if they differ")
+		pluralizeSuffixes  = flag.String("pluralize-suffixes", "", "Custom pluralization suffix rules as JSON file")
 
 		// Pluralization rules (advanced)
-		pluralizeSuffixesES     = flag.String("pluralize-es-suffixes", "s,x,z,ch,sh", "Comma-separated suffixes that get 'es' added")
-		pluralizeSuffixIES      = flag.String("pluralize-ies-suffix", "y", "Suffix that triggers 'ies' conversion")
-		pluralizeDefaultSuffix  = flag.String("pluralize-default-suffix", "s", "Default suffix to add for pluralization")
+		pluralizeSuffixesES    = flag.String("pluralize-es-suffixes", "s,x,z,ch,sh", "Comma-separated suffixes that get 'es' added")
+		pluralizeSuffixIES     = flag.String("pluralize-ies-suffix", "y", "Suffix that triggers 'ies' conversion")
+		pluralizeDefaultSuffix = flag.String("pluralize-default-suffix", "s", "Default suffix to add for pluralization")
+
+		// Security
+		authDirective      = flag.String("auth-directive", "", "Directive marking protected fields (e.g. \"auth\")")
+		securitySchemeType = flag.String("security-scheme", "bearer", "Security scheme for protected fields: bearer, basic or apiKey")
 
 		// CRUD prefixes (advanced)
 		crudPrefixCreate = flag.String("crud-prefix-create", "create", "Prefix for create operations in REST pattern detection")
@@ -38,21 +110,11 @@ func main() {
 	flag.BoolVar(help, "help", false, "Show help message")
 	flag.Parse()
 
-	if *help || *schemaFile == "" {
+	if *help {
 		printHelp()
-		if *schemaFile == "" {
-			os.Exit(1)
-		}
 		os.Exit(0)
 	}
 
-	// Read schema file
-	schemaBytes, err := os.ReadFile(*schemaFile)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading schema file: %v\n", err)
-		os.Exit(1)
-	}
-
 	// Load custom pluralization rules if provided
 	var customPlurals map[string]string
 	if *pluralizeSuffixes != "" {
@@ -78,48 +140,433 @@ func main() {
 		}
 	}
 
+	disabledResources := splitList(*disableREST)
+
+	var servers []converter.Server
+	for i, url := range baseURLs {
+		server := converter.Server{URL: url}
+		if i < len(serverDescriptions) {
+			server.Description = serverDescriptions[i]
+		}
+		servers = append(servers, server)
+	}
+
 	// Configure converter
 	config := converter.Config{
-		Title:              *title,
-		Version:            *version,
-		BaseURL:            *baseURL,
-		PathPrefix:         *pathPrefix,
-		DetectRESTPatterns: *detectRESTPatterns,
-		CustomPlurals:      customPlurals,
-		PluralizeSuffixesES:  esSuffixes,
-		PluralizeSuffixIES:   *pluralizeSuffixIES,
-		PluralizeDefaultSuffix: *pluralizeDefaultSuffix,
-		CRUDPrefixCreate:     *crudPrefixCreate,
-		CRUDPrefixUpdate:     *crudPrefixUpdate,
-		CRUDPrefixDelete:     *crudPrefixDelete,
+		Title:                   *title,
+		Version:                 *version,
+		ContactName:             *contactName,
+		ContactEmail:            *contactEmail,
+		ContactURL:              *contactURL,
+		LicenseName:             *licenseName,
+		LicenseURL:              *licenseURL,
+		TermsOfService:          *termsURL,
+		ExternalDocsURL:         *externalDocsURL,
+		ExternalDocsDescription: *externalDocsDesc,
+		Servers:                 servers,
+		PathPrefix:              *pathPrefix,
+		PrefixInServerURL:       *prefixInServerURL,
+		OmitFooter:              *noFooter,
+		PathCase:                *pathCase,
+		OpenAPIVersion:          *openAPIVersion,
+		IntFormat:               *intFormat,
+		IDFormat:                *idFormat,
+		StrictObjects:           *strictObjects,
+		DetectRESTPatterns:      *detectRESTPatterns,
+		IncludeGraphQLEndpoint:  *graphQLEndpoint,
+		CustomPlurals:           customPlurals,
+		DisableRESTResources:    disabledResources,
+		IncludeTypes:            splitList(*includeTypes),
+		ExcludeTypes:            splitList(*excludeTypes),
+		IncludeOperations:       splitList(*includeOperations),
+		ExcludeOperations:       splitList(*excludeOperations),
+		PluralizeSuffixesES:     esSuffixes,
+		PluralizeSuffixIES:      *pluralizeSuffixIES,
+		PluralizeDefaultSuffix:  *pluralizeDefaultSuffix,
+		CRUDPrefixCreate:        *crudPrefixCreate,
+		CRUDPrefixUpdate:        *crudPrefixUpdate,
+		CRUDPrefixDelete:        *crudPrefixDelete,
+		AuthDirective:           *authDirective,
+		SecuritySchemeType:      *securitySchemeType,
+	}
+
+	opts := runOptions{
+		schemaFile:      *schemaFile,
+		schemaFormat:    *schemaFormat,
+		outputFile:      *outputFile,
+		format:          *format,
+		stats:           *stats,
+		reportFile:      *report,
+		detectionReport: *detectionReport,
+		progress:        os.Stderr,
+		stdout:          os.Stdout,
+		lint:            *lint,
+		validate:        *validate,
+		dryRun:          *dryRun,
+	}
+	if *quiet {
+		opts.progress = io.Discard
+	}
+	// An explicit -title or -version wins over the schema's @info
+	flag.Visit(func(f *flag.Flag) {
+		config.TitleSet = config.TitleSet || f.Name == "title"
+		config.VersionSet = config.VersionSet || f.Name == "version"
+	})
+	if *configFile != "" {
+		if err := applyConfigFile(*configFile, &config, &opts); err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading config file: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if opts.schemaFile == "" {
+		printHelp()
+		os.Exit(1)
+	}
+
+	if err := run(config, opts); err != nil {
+		fmt.Fprintf(os.Stderr, "Error %v\n", err)
+		if !*watch {
+			os.Exit(1)
+		}
+	}
+	if *watch {
+		watchSchema(opts.schemaFile, opts.progress, func() error { return run(config, opts) })
+	}
+}
+
+// runOptions selects the input, output and reports of a single conversion
+type runOptions struct {
+	schemaFile      string
+	schemaFormat    string
+	outputFile      string
+	format          string
+	stats           bool
+	reportFile      string
+	detectionReport bool
+	lint            bool
+	validate        bool
+	dryRun          bool
+	progress        io.Writer // Where progress messages go; errors and warnings always go to stderr
+	stdout          io.Writer // Where the output goes when outputFile is "-"
+}
+
+// run reads the schema, converts it and writes the output. Errors are
+// returned rather than exiting so -watch can report them and keep going.
+func run(config converter.Config, opts runOptions) error {
+	var schemaSource string
+	switch strings.ToLower(opts.schemaFormat) {
+	case "sdl":
+		var err error
+		schemaSource, err = converter.LoadSDL(opts.schemaFile)
+		if err != nil {
+			return fmt.Errorf("reading schema file: %w", err)
+		}
+	case "introspection":
+		schemaBytes, err := os.ReadFile(opts.schemaFile)
+		if err != nil {
+			return fmt.Errorf("reading schema file: %w", err)
+		}
+		schemaSource, err = converter.IntrospectionToSDL(schemaBytes)
+		if err != nil {
+			return fmt.Errorf("reading introspection result: %w", err)
+		}
+	default:
+		return fmt.Errorf("reading schema: unknown schema format %q (expected sdl or introspection)", opts.schemaFormat)
 	}
 
 	// Convert
 	conv := converter.New(config)
-	openAPIDoc, err := conv.Convert(string(schemaBytes))
+	if format, ok := streamFormat(opts); ok {
+		return runStreamed(conv, schemaSource, format, opts)
+	}
+	openAPIDoc, err := conv.Convert(schemaSource)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error converting schema: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("converting schema: %w", err)
+	}
+
+	for _, warning := range conv.Warnings() {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	if opts.stats {
+		printStats(conv.Stats())
+	}
+
+	if opts.reportFile != "" {
+		report, err := json.MarshalIndent(conv.Report(), "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling report: %w", err)
+		}
+		if err := os.WriteFile(opts.reportFile, append(report, '\n'), 0644); err != nil {
+			return fmt.Errorf("writing report file: %w", err)
+		}
+	}
+
+	if opts.detectionReport {
+		printDetectionReport(conv.DetectionReport())
+		return nil
+	}
+	printDetected(conv, opts.progress)
+
+	if opts.lint {
+		for _, warning := range converter.Lint(openAPIDoc) {
+			fmt.Fprintf(os.Stderr, "Lint: %s\n", warning)
+		}
 	}
 
 	// Output
 	var output []byte
-	if strings.ToLower(*format) == "json" {
+	switch strings.ToLower(opts.format) {
+	case "json":
 		output, err = json.MarshalIndent(openAPIDoc, "", "  ")
-	} else {
+	case "postman":
+		output, err = converter.MarshalPostman(openAPIDoc)
+	case "jsonschema":
+		output, err = converter.MarshalJSONSchema(openAPIDoc)
+	default:
 		output, err = converter.MarshalYAML(openAPIDoc)
 	}
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error marshaling output: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("marshaling output: %w", err)
 	}
 
-	if err := os.WriteFile(*outputFile, output, 0644); err != nil {
-		fmt.Fprintf(os.Stderr, "Error writing output file: %v\n", err)
-		os.Exit(1)
+	destination := opts.outputFile
+	if opts.dryRun {
+		if destination == "-" {
+			return fmt.Errorf("-dry-run needs an -output file to compare against")
+		}
+		existing, err := os.ReadFile(destination)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("reading output file: %w", err)
+		}
+		if err == nil && bytes.Equal(existing, output) {
+			fmt.Fprintf(opts.progress, "%s is up to date\n", destination)
+		} else if err != nil {
+			return fmt.Errorf("%s does not exist yet", destination)
+		} else {
+			return fmt.Errorf("%s is out of date: %s", destination, diffSummary(existing, output))
+		}
+	} else if destination == "-" {
+		if _, err := opts.stdout.Write(output); err != nil {
+			return fmt.Errorf("writing output: %w", err)
+		}
+		destination = "stdout"
+	} else if err := os.WriteFile(opts.outputFile, output, 0644); err != nil {
+		return fmt.Errorf("writing output file: %w", err)
 	}
 
-	fmt.Printf("Successfully converted %s to %s\n", filepath.Base(*schemaFile), *outputFile)
+	if !opts.dryRun {
+		fmt.Fprintf(opts.progress, "Successfully converted %s to %s\n", filepath.Base(opts.schemaFile), destination)
+	}
+
+	if opts.validate {
+		errs := converter.Validate(openAPIDoc)
+		for _, err := range errs {
+			fmt.Fprintf(os.Stderr, "Validation error: %v\n", err)
+		}
+		if len(errs) > 0 {
+			return fmt.Errorf("validating output: %d error(s) found", len(errs))
+		}
+	}
+	return nil
+}
+
+// printDetected lists the REST patterns the conversion consolidated
+func printDetected(conv *converter.Converter, progress io.Writer) {
+	for _, report := range conv.DetectionReport() {
+		if report.Status == "consolidated" {
+			fmt.Fprintf(progress, "Detected REST pattern '%s': consolidated %d operations -> %s\n",
+				report.Resource, len(report.Operations), report.Path)
+		}
+	}
+}
+
+// streamFormat returns the format to stream the output in when nothing but
+// plain YAML or JSON output is asked for, which needs no document afterwards
+func streamFormat(opts runOptions) (string, bool) {
+	if opts.stats || opts.reportFile != "" || opts.detectionReport || opts.lint || opts.validate || opts.dryRun {
+		return "", false
+	}
+	switch strings.ToLower(opts.format) {
+	case "postman", "jsonschema":
+		return "", false
+	case "json":
+		return "json", true
+	default:
+		return "yaml", true
+	}
+}
+
+// runStreamed converts and writes the output with ConvertStream, which never
+// holds the whole paths section in memory
+func runStreamed(conv *converter.Converter, schemaSource, format string, opts runOptions) error {
+	destination := "stdout"
+	output := &lazyFile{path: opts.outputFile}
+	var w io.Writer = output
+	if opts.outputFile == "-" {
+		w = opts.stdout
+	} else {
+		destination = opts.outputFile
+	}
+	err := conv.ConvertStream(schemaSource, w, format)
+	if closeErr := output.Close(); err == nil && closeErr != nil {
+		return fmt.Errorf("writing output file: %w", closeErr)
+	}
+	if err != nil {
+		return fmt.Errorf("converting schema: %w", err)
+	}
+
+	for _, warning := range conv.Warnings() {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	printDetected(conv, opts.progress)
+	fmt.Fprintf(opts.progress, "Successfully converted %s to %s\n", filepath.Base(opts.schemaFile), destination)
+	return nil
+}
+
+// lazyFile creates the file at path on the first write, so a schema that
+// fails to convert leaves an existing output file untouched
+type lazyFile struct {
+	path string
+	file *os.File
+}
+
+func (f *lazyFile) Write(p []byte) (int, error) {
+	if f.file == nil {
+		file, err := os.Create(f.path)
+		if err != nil {
+			return 0, err
+		}
+		f.file = file
+	}
+	return f.file.Write(p)
+}
+
+// Close closes the file, if it was ever created
+func (f *lazyFile) Close() error {
+	if f.file == nil {
+		return nil
+	}
+	return f.file.Close()
+}
+
+// diffSummary describes how the lines of after differ from before: the first
+// differing line, and how many lines were added and removed
+func diffSummary(before, after []byte) string {
+	beforeLines := strings.Split(string(before), "\n")
+	afterLines := strings.Split(string(after), "\n")
+
+	first := 0
+	for first < len(beforeLines) && first < len(afterLines) && beforeLines[first] == afterLines[first] {
+		first++
+	}
+
+	// Lines are compared as multisets, so moved lines count as unchanged
+	counts := make(map[string]int)
+	for _, line := range beforeLines {
+		counts[line]++
+	}
+	added := 0
+	for _, line := range afterLines {
+		if counts[line] > 0 {
+			counts[line]--
+		} else {
+			added++
+		}
+	}
+	removed := 0
+	for _, n := range counts {
+		removed += n
+	}
+	return fmt.Sprintf("first difference at line %d, %d line(s) added, %d removed", first+1, added, removed)
+}
+
+// watchSchema polls the schema file and calls regenerate whenever its
+// modification time changes, until the process is interrupted
+func watchSchema(schemaFile string, progress io.Writer, regenerate func() error) {
+	fmt.Fprintf(progress, "Watching %s for changes (Ctrl+C to stop)\n", schemaFile)
+	lastModified := modTime(schemaFile)
+	for range time.Tick(500 * time.Millisecond) {
+		modified := modTime(schemaFile)
+		if modified.IsZero() || modified.Equal(lastModified) {
+			continue
+		}
+		lastModified = modified
+		stamp := time.Now().Format("15:04:05")
+		if err := regenerate(); err != nil {
+			fmt.Fprintf(os.Stderr, "[%s] Error %v\n", stamp, err)
+			continue
+		}
+		fmt.Fprintf(progress, "[%s] Regenerated from %s\n", stamp, filepath.Base(schemaFile))
+	}
+}
+
+// modTime returns the file's modification time, or the zero time if it
+// cannot be read (e.g. while an editor is replacing it)
+func modTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var list []string
+	for _, s := range strings.Split(value, ",") {
+		if trimmed := strings.TrimSpace(s); trimmed != "" {
+			list = append(list, trimmed)
+		}
+	}
+	return list
+}
+
+func printStats(stats converter.Stats) {
+	fmt.Fprintf(os.Stderr, "Types:         %s\n", formatCounts(stats.TypesByKind))
+	fmt.Fprintf(os.Stderr, "Operations:    %s\n", formatCounts(stats.OperationsByMethod))
+	fmt.Fprintf(os.Stderr, "REST patterns: %d\n", stats.RESTPatterns)
+	fmt.Fprintf(os.Stderr, "Warnings:      %d\n", stats.Warnings)
+	unmapped := "none"
+	if len(stats.UnmappedScalars) > 0 {
+		unmapped = strings.Join(stats.UnmappedScalars, ", ")
+	}
+	fmt.Fprintf(os.Stderr, "Unmapped scalars: %s\n", unmapped)
+}
+
+// formatCounts renders counts as "key=n" pairs in key order
+func formatCounts(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	total := 0
+	for key, n := range counts {
+		keys = append(keys, key)
+		total += n
+	}
+	sort.Strings(keys)
+	parts := []string{}
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%d", key, counts[key]))
+	}
+	if len(parts) == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("%d (%s)", total, strings.Join(parts, ", "))
+}
+
+func printDetectionReport(reports []converter.DetectionReport) {
+	for _, report := range reports {
+		ops := []string{}
+		for _, op := range []string{"list", "get", "create", "update", "delete"} {
+			if field, ok := report.Operations[op]; ok {
+				ops = append(ops, op+"="+field)
+			}
+		}
+		fmt.Fprintf(os.Stderr, "%-12s %-20s %s [%s]", report.Status, report.Resource, report.Path, strings.Join(ops, ", "))
+		if report.Reason != "" {
+			fmt.Fprintf(os.Stderr, " - %s", report.Reason)
+		}
+		fmt.Fprintln(os.Stderr)
+	}
 }
 
 func printHelp() {
@@ -129,13 +576,71 @@ Usage: graphql-to-openapi [options]
 
 Basic Options:
   -schema string
-        GraphQL schema file (required)
+        GraphQL schema file (required, unless set in -config)
+
+  -config string
+        YAML or JSON file of converter settings, keyed like converter.Config's
+        fields in camelCase (title, pathPrefix, customPlurals, singletonQueries,
+        ...) plus schema, schemaFormat, output and format; flags given on the
+        command line override the file
+
+  -schema-format string
+        Schema file format: sdl or introspection (default "sdl")
+        introspection reads the JSON result of a standard introspection query
 
   -output string
-        Output OpenAPI file (default "openapi.yaml")
+        Output OpenAPI file, or - for stdout (default "openapi.yaml")
 
   -format string
-        Output format: yaml or json (default "yaml")
+        Output format: yaml, json, postman or jsonschema (default "yaml")
+        postman writes a Postman v2.1 collection, foldered by tag
+        jsonschema writes only the component schemas, as JSON Schema 2020-12 $defs
+
+  -int-format string
+        Format for GraphQL Int: int32 or int64 (default "int32")
+        int64 suits schemas whose Int fields carry values beyond 32 bits
+
+  -id-format string
+        Shape of GraphQL ID values, including id path parameters: uuid
+        (string, format uuid) or int64 (integer); plain string by default
+
+  -strict-objects
+        Set additionalProperties: false on object and input type schemas and
+        request bodies, so contract tests reject unknown properties; interfaces,
+        unions and types implementing interfaces stay permissive
+
+  -validate
+        Check the generated spec for structural errors (dangling $refs, paths
+        without operations, operations without responses, duplicate
+        operationIds); errors go to stderr and the exit status is non-zero
+
+  -stats
+        Print a conversion summary to stderr: types by kind, operations by
+        method, REST patterns detected, warnings and unmapped scalars
+
+  -report string
+        Write a JSON conversion report to this file: types by kind, paths
+        and operations by method, REST detection decisions, directives the
+        converter ignored, and warnings. Useful as a CI artifact
+
+  -lint
+        Print style warnings about the generated spec to stderr (missing
+        summaries/descriptions, single-value enums, unused components, ...)
+
+  -watch
+        After converting, keep watching the schema file and regenerate the
+        output on every change; errors are reported without stopping
+
+  -quiet
+        Suppress progress messages (detected REST patterns, "Successfully
+        converted ..."); warnings and errors still go to stderr. Progress
+        messages always go to stderr, keeping stdout free for the output
+
+  -dry-run
+        Convert and compare the result with the existing -output file instead
+        of writing it, reporting the first differing line and how many lines
+        were added and removed; the exit status is non-zero if they differ,
+        so CI can check a checked-in spec is current
 
 API Metadata:
   -title string
@@ -145,21 +650,84 @@ API Metadata:
         API version (default "1.0.0")
 
   -base-url string
-        Base URL for the API
+        Base URL for the API (repeatable or comma-separated)
+        Example: -base-url https://api.example.com -base-url https://staging.example.com
+
+  -server-description string
+        Description for each -base-url, in order (repeat the flag for each;
+        commas are kept as part of the description)
 
   -path-prefix string
         Path prefix for all endpoints (e.g., "/api/v1")
 
+  -prefix-in-server-url
+        Append -path-prefix to the server URLs instead of the path keys
+
+  -path-case string
+        Case of path segments derived from field names: camel, kebab or snake (default "camel")
+        Example: productCategories -> /product-categories with kebab
+
+  -contact-name, -contact-email, -contact-url string
+        API contact information (info.contact)
+
+  -license-name, -license-url string
+        API license information (info.license); -license-url needs -license-name
+
+  -terms-url string
+        API terms of service URL (info.termsOfService)
+
+  -external-docs-url, -external-docs-description string
+        Link to external API documentation (externalDocs); fields can add
+        their own with @externalDocs(url: ..., description: ...)
+
+  -openapi-version string
+        OpenAPI version to emit: 3.0.0 or 3.1.0 (default "3.0.0")
+
+  -no-footer
+        Leave the "Converted from GraphQL (version)" footer out of
+        info.description; without a schema description, info.description is
+        omitted
+
+Filtering:
+  -include-types, -exclude-types string
+        Comma-separated types to convert / leave out, by name or glob
+        Example: -exclude-types "Internal*,AuditLog"
+
+  -include-operations, -exclude-operations string
+        Comma-separated query/mutation/subscription fields to convert / leave out
+        Example: -exclude-operations "admin*"
+
 REST Pattern Detection:
   -detect-rest-patterns
         Enable REST pattern detection (default true)
         Detects CRUD patterns and consolidates them into REST endpoints
 
+  -disable-rest-resources string
+        Comma-separated resources to exclude from REST consolidation
+        Example: "user,post" keeps /users, /createUser, ... as plain endpoints
+
+  -include-graphql-endpoint
+        Also document the GraphQL endpoint itself: POST /graphql (under any
+        -path-prefix) taking {query, variables, operationName}
+
+  -detection-report
+        Print REST pattern detection decisions (consolidated or filtered,
+        with the reason) to stderr without writing output
+
   -pluralize-suffixes string
         Custom pluralization suffix rules as JSON file
         Matches and replaces word endings (suffix match, not whole word)
         Example: {"person": "people", "child": "children", "data": "data"}
 
+Security:
+  -auth-directive string
+        Directive marking protected fields (e.g. "auth", "hasRole")
+        Operations for annotated fields get a security requirement;
+        role/scope arguments are listed under x-roles
+
+  -security-scheme string
+        Security scheme for protected fields: bearer, basic or apiKey (default "bearer")
+
 Advanced: Pluralization Rules
   -pluralize-es-suffixes string
         Comma-separated suffixes that get 'es' added (default "s,x,z,ch,sh")
@@ -194,6 +762,9 @@ Examples:
   # Basic conversion
   graphql-to-openapi -schema schema.graphql -output api.yaml
 
+  # Write to stdout for a pipeline
+  graphql-to-openapi -schema schema.graphql -output - | yq '.paths | keys'
+
   # With API metadata and versioning
   graphql-to-openapi -schema schema.graphql \
     -title "My API" \
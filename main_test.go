@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/choonkeat/graphql-to-openapi/converter"
+)
+
+func TestListFlags(t *testing.T) {
+	var urls stringList
+	var descriptions repeatedList
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.Var(&urls, "base-url", "")
+	flags.Var(&descriptions, "server-description", "")
+	err := flags.Parse([]string{
+		"-base-url", "https://a.example.com, https://b.example.com",
+		"-server-description", "Production, EU",
+		"-server-description", "Staging",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (stringList{"https://a.example.com", "https://b.example.com"}); !reflect.DeepEqual(urls, want) {
+		t.Errorf("base-url = %q, want %q", urls, want)
+	}
+	if want := (repeatedList{"Production, EU", "Staging"}); !reflect.DeepEqual(descriptions, want) {
+		t.Errorf("server-description = %q, want %q", descriptions, want)
+	}
+}
+
+func TestModTime(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schema.graphql")
+	if !modTime(path).IsZero() {
+		t.Error("a missing file should have no modification time")
+	}
+	if err := os.WriteFile(path, []byte("type Query { a: String }"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if modTime(path).IsZero() {
+		t.Error("an existing file should have a modification time")
+	}
+}
+
+// writeSchema writes sdl to a schema file in a temporary directory
+func writeSchema(t *testing.T, sdl string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "schema.graphql")
+	if err := os.WriteFile(path, []byte(sdl), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const crudSchema = `
+type User { id: ID! }
+input UserInput { name: String! }
+type Query { users: [User!]!, user(id: ID!): User }
+type Mutation { createUser(input: UserInput!): User! }
+`
+
+func TestRunReportsDetections(t *testing.T) {
+	var progress, stdout bytes.Buffer
+	err := run(converter.DefaultConfig(), runOptions{
+		schemaFile:   writeSchema(t, crudSchema),
+		schemaFormat: "sdl",
+		outputFile:   "-",
+		format:       "yaml",
+		progress:     &progress,
+		stdout:       &stdout,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Detected REST pattern 'user': consolidated 3 operations -> /users\n"; !strings.Contains(progress.String(), want) {
+		t.Errorf("progress = %q, want %q", progress.String(), want)
+	}
+	if strings.Contains(stdout.String(), "Detected") {
+		t.Error("detections should not be mixed into the output")
+	}
+}
+
+func TestRunQuietProgress(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "openapi.yaml")
+	var stdout bytes.Buffer
+	err := run(converter.DefaultConfig(), runOptions{
+		schemaFile:   writeSchema(t, crudSchema),
+		schemaFormat: "sdl",
+		outputFile:   output,
+		format:       "yaml",
+		progress:     io.Discard,
+		stdout:       &stdout,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("stdout = %q, want nothing when writing to a file", stdout.String())
+	}
+	if _, err := os.Stat(output); err != nil {
+		t.Errorf("output file not written: %v", err)
+	}
+}
+
+func TestRunStdout(t *testing.T) {
+	for _, format := range []string{"yaml", "json"} {
+		var progress, stdout bytes.Buffer
+		err := run(converter.DefaultConfig(), runOptions{
+			schemaFile:   writeSchema(t, crudSchema),
+			schemaFormat: "sdl",
+			outputFile:   "-",
+			format:       format,
+			progress:     &progress,
+			stdout:       &stdout,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		prefix := map[string]string{"yaml": "openapi: 3.0.0", "json": "{\n  \"openapi\": \"3.0.0\""}[format]
+		if !strings.HasPrefix(stdout.String(), prefix) {
+			t.Errorf("%s: stdout starts %.40q, want %q", format, stdout.String(), prefix)
+		}
+		if !strings.Contains(progress.String(), "to stdout") {
+			t.Errorf("%s: progress = %q, want the stdout destination", format, progress.String())
+		}
+	}
+}
+
+func TestRunDryRun(t *testing.T) {
+	schema := writeSchema(t, crudSchema)
+	output := filepath.Join(t.TempDir(), "openapi.yaml")
+	dryRun := func(t *testing.T) (string, error) {
+		var progress bytes.Buffer
+		err := run(converter.DefaultConfig(), runOptions{
+			schemaFile:   schema,
+			schemaFormat: "sdl",
+			outputFile:   output,
+			format:       "yaml",
+			dryRun:       true,
+			progress:     &progress,
+			stdout:       io.Discard,
+		})
+		return progress.String(), err
+	}
+
+	if _, err := dryRun(t); err == nil || !strings.Contains(err.Error(), "does not exist yet") {
+		t.Errorf("missing output: err = %v", err)
+	}
+	if err := os.WriteFile(output, []byte("openapi: 3.0.0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dryRun(t); err == nil || !strings.Contains(err.Error(), "is out of date") {
+		t.Errorf("stale output: err = %v", err)
+	}
+	if data, _ := os.ReadFile(output); string(data) != "openapi: 3.0.0\n" {
+		t.Error("-dry-run overwrote the output")
+	}
+
+	err := run(converter.DefaultConfig(), runOptions{schemaFile: schema, schemaFormat: "sdl", outputFile: output, format: "yaml", progress: io.Discard, stdout: io.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if progress, err := dryRun(t); err != nil || !strings.Contains(progress, "is up to date") {
+		t.Errorf("current output: progress = %q, err = %v", progress, err)
+	}
+}
+
+func TestRunStreamedOutputFile(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(output, []byte("keep\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	convert := func(schema string) error {
+		return run(converter.DefaultConfig(), runOptions{schemaFile: schema, schemaFormat: "sdl", outputFile: output, format: "yaml", progress: io.Discard, stdout: io.Discard})
+	}
+
+	if err := convert(writeSchema(t, "type Query {")); err == nil {
+		t.Fatal("expected an error for an invalid schema")
+	}
+	if data, _ := os.ReadFile(output); string(data) != "keep\n" {
+		t.Errorf("failed conversion wrote the output: %q", data)
+	}
+
+	if err := convert(writeSchema(t, crudSchema)); err != nil {
+		t.Fatal(err)
+	}
+	want, err := converter.New(converter.DefaultConfig()).Convert(crudSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantYAML, err := converter.MarshalYAML(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(output); string(data) != string(wantYAML) {
+		t.Error("streamed output differs from Convert")
+	}
+}